@@ -0,0 +1,64 @@
+// Package coursecode canonicalizes course codes so that codes written as
+// "BLG102E", "blg 102e" or "BLG  102E" all compare equal.
+package coursecode
+
+import (
+	"regexp"
+	"strings"
+)
+
+// codePattern captures the department, number, optional language suffix and
+// optional laboratory "L" of a course code
+var codePattern = regexp.MustCompile(`^([A-Z]{2,4})\s*(\d{3,4})\s*([A-KM-Z]?)(L?)$`)
+
+// Normalize canonicalizes a course code to "DEPT NUM[SUFFIX]" with a single
+// space, e.g. "BLG102E" becomes "BLG 102E". Codes that don't look like a
+// course code are returned trimmed, upper-cased and with single spaces.
+func Normalize(code string) string {
+	code = strings.ToUpper(strings.Join(strings.Fields(code), " "))
+
+	match := codePattern.FindStringSubmatch(code)
+	if match == nil {
+		return code
+	}
+	return match[1] + " " + match[2] + match[3] + match[4]
+}
+
+// Equal reports whether two course codes name the same course
+func Equal(a, b string) bool {
+	return Normalize(a) == Normalize(b)
+}
+
+// Base returns the normalized code without its language suffix, e.g. "BLG 102E"
+// becomes "BLG 102". A laboratory "L" is kept since labs are separate courses.
+func Base(code string) string {
+	code = Normalize(code)
+	match := codePattern.FindStringSubmatch(code)
+	if match == nil {
+		return code
+	}
+	return match[1] + " " + match[2] + match[4]
+}
+
+// suffix returns the language suffix of a normalized course code, if any
+func suffix(code string) string {
+	match := codePattern.FindStringSubmatch(code)
+	if match == nil {
+		return ""
+	}
+	return match[3]
+}
+
+// Matches reports whether two course codes can name the same course. Codes
+// match on their base code; the language suffix only has to agree when both
+// codes carry one, since the parser strips it from Turkish-taught courses.
+func Matches(a, b string) bool {
+	a, b = Normalize(a), Normalize(b)
+	if a == b {
+		return true
+	}
+	if Base(a) != Base(b) {
+		return false
+	}
+	return suffix(a) == "" || suffix(b) == ""
+}
@@ -0,0 +1,75 @@
+package coursecode
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"BLG 102E", "BLG 102E"},
+		{"BLG102E", "BLG 102E"},
+		{"blg 102e", "BLG 102E"},
+		{"BLG  102E", "BLG 102E"},
+		{" BLG 102E\t", "BLG 102E"},
+		{"BLG 102 E", "BLG 102E"},
+		{"MAT103", "MAT 103"},
+		{"FIZ101EL", "FIZ 101EL"},
+		{"KIM 101L", "KIM 101L"},
+		{"ATA 121", "ATA 121"},
+		{"not a code", "NOT A CODE"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := Normalize(tt.code); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBase(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"BLG102E", "BLG 102"},
+		{"BLG 102", "BLG 102"},
+		{"FIZ 101EL", "FIZ 101L"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := Base(tt.code); got != tt.want {
+				t.Errorf("Base(%q) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualAndMatches(t *testing.T) {
+	tests := []struct {
+		a, b        string
+		wantEqual   bool
+		wantMatches bool
+	}{
+		{"BLG102E", "BLG 102E", true, true},
+		{"blg 102e", "BLG  102E", true, true},
+		{"BLG 102E", "BLG 102", false, true},
+		{"BLG 102E", "BLG 102T", false, false},
+		{"BLG 102E", "BLG 103E", false, false},
+		{"FIZ 101E", "FIZ 101EL", false, false},
+		{"FIZ 101EL", "FIZ 101L", false, true}, // lab code with the suffix stripped
+		{"BLG102", "blg 102e", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"|"+tt.b, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b); got != tt.wantEqual {
+				t.Errorf("Equal(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.wantEqual)
+			}
+			if got := Matches(tt.a, tt.b); got != tt.wantMatches {
+				t.Errorf("Matches(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.wantMatches)
+			}
+		})
+	}
+}
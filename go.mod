@@ -4,5 +4,8 @@ go 1.18
 
 require (
 	encore.dev v1.46.1
+	github.com/jung-kurt/gofpdf v1.16.2
 	github.com/ledongthuc/pdf v0.0.0-20220302134840-0c2507a12d80
+	golang.org/x/image v0.18.0
+	golang.org/x/text v0.16.0
 )
@@ -0,0 +1,69 @@
+// Package grades classifies transcript grades for the services that have to
+// agree on them: the transcript service's credit and GPA math and the plan
+// service's course matching.
+package grades
+
+import (
+	"strconv"
+	"strings"
+)
+
+// inProgressGrades are the grade markers meaning a course has no grade yet.
+// Such courses are listed with InProgress set but never count toward GPA or
+// earned credits, nor complete a plan slot. The defaults are "--" and an empty
+// grade as printed on ITU transcripts, "Devam" (continuing) and "NG" (no grade).
+var inProgressGrades = []string{"--", "", "Devam", "NG"}
+
+// withdrawalGrades are the grade markers of courses the student withdrew from.
+// Withdrawn courses are neither failures nor passes: they count toward attempted
+// credits only, never toward the GPA or earned credits, and complete no plan slot.
+var withdrawalGrades = []string{"W"}
+
+// exchangeFailingGrades are the letter grades that fail an exchange (Erasmus)
+// course: F on the ECTS scale and NP on pass/no-pass transcripts.
+var exchangeFailingGrades = []string{"F", "NP"}
+
+// exchangeNumericPassLimit is the worst passing numeric exchange grade. Numeric
+// grades follow the German scale, where 1.0 is best and anything above 4.0 fails.
+var exchangeNumericPassLimit = 4.0
+
+// IsInProgress reports whether grade is one of the configured inProgressGrades
+func IsInProgress(grade string) bool {
+	for _, marker := range inProgressGrades {
+		if grade == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWithdrawn reports whether grade is one of the configured withdrawalGrades
+func IsWithdrawn(grade string) bool {
+	for _, marker := range withdrawalGrades {
+		if grade == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// Markers returns the configured in-progress and withdrawal markers, for
+// recognizing them in transcript text
+func Markers() []string {
+	markers := make([]string, 0, len(inProgressGrades)+len(withdrawalGrades))
+	markers = append(markers, inProgressGrades...)
+	return append(markers, withdrawalGrades...)
+}
+
+// IsExchangeFailure reports whether grade fails an exchange course, either as a
+// failing letter grade or as a numeric grade beyond the passing limit
+func IsExchangeFailure(grade string) bool {
+	for _, failing := range exchangeFailingGrades {
+		if grade == failing {
+			return true
+		}
+	}
+
+	numeric, err := strconv.ParseFloat(strings.Replace(grade, ",", ".", 1), 64)
+	return err == nil && numeric > exchangeNumericPassLimit
+}
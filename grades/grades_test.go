@@ -0,0 +1,63 @@
+package grades
+
+import "testing"
+
+func TestIsInProgress(t *testing.T) {
+	tests := []struct {
+		grade string
+		want  bool
+	}{
+		{"--", true},
+		{"", true},
+		{"Devam", true},
+		{"NG", true},
+		{"AA", false},
+		{"FF", false},
+		{"W", false},
+	}
+	for _, tt := range tests {
+		if got := IsInProgress(tt.grade); got != tt.want {
+			t.Errorf("IsInProgress(%q) = %v, want %v", tt.grade, got, tt.want)
+		}
+	}
+}
+
+func TestIsWithdrawn(t *testing.T) {
+	if !IsWithdrawn("W") {
+		t.Error(`IsWithdrawn("W") = false, want true`)
+	}
+	if IsWithdrawn("FF") {
+		t.Error(`IsWithdrawn("FF") = true, want false`)
+	}
+
+	defer func(configured []string) { withdrawalGrades = configured }(withdrawalGrades)
+	withdrawalGrades = []string{"WD"}
+	if !IsWithdrawn("WD") || IsWithdrawn("W") {
+		t.Error("IsWithdrawn doesn't follow the configured withdrawal grades")
+	}
+	if markers := Markers(); markers[len(markers)-1] != "WD" {
+		t.Errorf("Markers() = %q, want the configured withdrawal grade included", markers)
+	}
+}
+
+func TestIsExchangeFailure(t *testing.T) {
+	tests := []struct {
+		grade string
+		want  bool
+	}{
+		{"A", false},
+		{"E", false},
+		{"F", true},
+		{"P", false},
+		{"NP", true},
+		{"1.0", false},
+		{"4.0", false},
+		{"4,3", true},
+		{"5.0", true},
+	}
+	for _, tt := range tests {
+		if got := IsExchangeFailure(tt.grade); got != tt.want {
+			t.Errorf("IsExchangeFailure(%q) = %v, want %v", tt.grade, got, tt.want)
+		}
+	}
+}
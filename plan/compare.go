@@ -0,0 +1,92 @@
+package plan
+
+import (
+	"context"
+	"sync"
+
+	"encore.app/transcript"
+	"encore.dev/beta/errs"
+)
+
+// CompareProgressRequest names the two students to compare
+type CompareProgressRequest struct {
+	UserA string `query:"userA"`
+	UserB string `query:"userB"`
+}
+
+// StudentProgress is one side of a progress comparison. Fields that couldn't be
+// computed are left empty and the reason is reported in Error.
+type StudentProgress struct {
+	UserID           string  `json:"userId"`
+	CompletedCredits float64 `json:"completedCredits"`
+	CGPA             float64 `json:"cgpa"`
+	// RemainingRequired lists the mandatory plan slots not completed yet; nil without a plan
+	RemainingRequired []Course `json:"remainingRequired,omitempty"`
+	RemainingCredits  float64  `json:"remainingCredits"`
+	Error             string   `json:"error,omitempty"`
+}
+
+// CompareProgressResponse holds the two students side by side
+type CompareProgressResponse struct {
+	A StudentProgress `json:"a"`
+	B StudentProgress `json:"b"`
+}
+
+// CompareProgress is an advisor view comparing two students against their plans.
+// A student without a plan or transcript still gets whatever could be computed.
+//
+//encore:api public method=GET path=/progress/compare
+func CompareProgress(ctx context.Context, req *CompareProgressRequest) (*CompareProgressResponse, error) {
+	if req.UserA == "" || req.UserB == "" {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "userA and userB are required",
+		}
+	}
+
+	resp := &CompareProgressResponse{}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		resp.A = studentProgress(ctx, req.UserA)
+	}()
+	go func() {
+		defer wg.Done()
+		resp.B = studentProgress(ctx, req.UserB)
+	}()
+	wg.Wait()
+
+	return resp, nil
+}
+
+// studentProgress computes one side of a progress comparison
+func studentProgress(ctx context.Context, userID string) StudentProgress {
+	progress := StudentProgress{UserID: userID}
+
+	gpa, err := transcript.GetGPA(ctx, userID, &transcript.GPARequest{})
+	if err != nil {
+		progress.Error = err.Error()
+		return progress
+	}
+	progress.CGPA = gpa.GPA
+	progress.CompletedCredits = gpa.EarnedCredits
+
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		progress.Error = err.Error()
+		return progress
+	}
+
+	progress.RemainingRequired = []Course{}
+	for _, match := range matchPlan(plan.PlanJSON, courses, eq) {
+		if match.Course != nil {
+			continue
+		}
+		progress.RemainingCredits += match.Slot.Credits
+		if match.Slot.isMandatory() {
+			progress.RemainingRequired = append(progress.RemainingRequired, match.Slot)
+		}
+	}
+	return progress
+}
@@ -0,0 +1,42 @@
+package plan
+
+import "regexp"
+
+// Service-wide tunables for the plan service.
+
+// defaultNextSemesterCredits is the load in local credits, the unit of a plan
+// slot's Credits, that next-semester recommendations are limited to when the
+// request doesn't specify one. A regular semester of about 30 ECTS carries
+// roughly 21 local credits.
+var defaultNextSemesterCredits = 21.0
+
+// defaultForecastCreditsPerSemester is the average credit load the graduation
+// forecast assumes when the request doesn't specify one.
+var defaultForecastCreditsPerSemester = 30.0
+
+// graduationProjectCodes are the course codes of graduation projects (bitirme
+// tasarım projesi). A course also counts as one when its name matches
+// graduationProjectNamePattern.
+var graduationProjectCodes = []string{
+	"BLG 4901E", "BLG 4902E",
+	"ELK 4901E", "ELK 4902E",
+	"END 4901E", "END 4902E",
+	"INS 4901E", "INS 4902E",
+	"MAK 4901E", "MAK 4902E",
+}
+
+// graduationProjectNamePattern matches graduation project course names
+var graduationProjectNamePattern = regexp.MustCompile(`(?i)bitirme|graduation (design )?project`)
+
+// defaultCourseEquivalencies maps retired course codes to their replacements
+// (old code -> new code, many-to-one). Entries stored in the course_equivalency
+// table take precedence.
+var defaultCourseEquivalencies = map[string]string{}
+
+// creditMismatchTolerance is how far a matched course's parsed credits may be
+// from its plan slot's credits before the pair is reported as a mismatch.
+var creditMismatchTolerance = 0.01
+
+// defaultImpactGrade is the grade assumed for every remaining course by the GPA
+// impact recommendation when the request doesn't name one.
+var defaultImpactGrade = "AA"
@@ -16,6 +16,11 @@ type Course struct {
 	Name     string   `json:"name,omitempty"`
 	Category string   `json:"category,omitempty"`
 	Options  []string `json:"options,omitempty"`
+	Credits  float64  `json:"credits,omitempty"`
+	// ECTS is the course's AKTS value, used for Bologna/exchange progress
+	ECTS float64 `json:"ects,omitempty"`
+	// Prerequisites lists course codes that must be passed before taking this course
+	Prerequisites []string `json:"prerequisites,omitempty"`
 }
 
 // PlanData represents the structure of the plan JSON - array of semesters (each semester is an array of courses)
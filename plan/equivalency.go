@@ -0,0 +1,109 @@
+package plan
+
+import (
+	"context"
+	"sort"
+
+	"encore.app/coursecode"
+	"encore.dev/beta/errs"
+)
+
+// equivalencies maps normalized retired course codes to the normalized codes that replaced them
+type equivalencies map[string]string
+
+// satisfies reports whether a transcript course code fulfils a required code,
+// either directly or through a chain of renamed or renumbered codes. Codes are
+// compared with coursecode.Matches, so a suffix stripped by the parser still matches.
+func (e equivalencies) satisfies(courseCode, requiredCode string) bool {
+	code := coursecode.Normalize(courseCode)
+	// Bound the walk by the map size so a cyclic mapping can't loop forever
+	for i := 0; i <= len(e); i++ {
+		if coursecode.Matches(code, requiredCode) {
+			return true
+		}
+		next, ok := e[code]
+		if !ok {
+			next, ok = e[coursecode.Base(code)]
+		}
+		if !ok {
+			return false
+		}
+		code = next
+	}
+	return false
+}
+
+// loadEquivalencies merges defaultCourseEquivalencies with the stored mappings
+func loadEquivalencies(ctx context.Context) (equivalencies, error) {
+	stored, err := GetCourseEquivalencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eq := make(equivalencies, len(defaultCourseEquivalencies)+len(stored))
+	for oldCode, newCode := range defaultCourseEquivalencies {
+		eq[coursecode.Normalize(oldCode)] = coursecode.Normalize(newCode)
+	}
+	for oldCode, newCode := range stored {
+		eq[coursecode.Normalize(oldCode)] = coursecode.Normalize(newCode)
+	}
+	return eq, nil
+}
+
+// CourseEquivalency maps a retired course code to the code that replaced it
+type CourseEquivalency struct {
+	OldCode string `json:"oldCode"`
+	NewCode string `json:"newCode"`
+}
+
+// CourseEquivalenciesResponse lists the active course equivalencies
+type CourseEquivalenciesResponse struct {
+	Equivalencies []CourseEquivalency `json:"equivalencies"`
+}
+
+//encore:api public method=GET path=/course-equivalencies
+func ListCourseEquivalencies(ctx context.Context) (*CourseEquivalenciesResponse, error) {
+	eq, err := loadEquivalencies(ctx)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve course equivalencies",
+		}
+	}
+
+	resp := &CourseEquivalenciesResponse{Equivalencies: []CourseEquivalency{}}
+	for oldCode, newCode := range eq {
+		resp.Equivalencies = append(resp.Equivalencies, CourseEquivalency{OldCode: oldCode, NewCode: newCode})
+	}
+	sort.Slice(resp.Equivalencies, func(i, j int) bool {
+		return resp.Equivalencies[i].OldCode < resp.Equivalencies[j].OldCode
+	})
+	return resp, nil
+}
+
+//encore:api public method=POST path=/course-equivalencies
+func AddCourseEquivalency(ctx context.Context, req *CourseEquivalency) (*CourseEquivalency, error) {
+	oldCode := coursecode.Normalize(req.OldCode)
+	newCode := coursecode.Normalize(req.NewCode)
+	if oldCode == "" || newCode == "" {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "oldCode and newCode are required",
+		}
+	}
+	if oldCode == newCode {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "oldCode and newCode must differ",
+		}
+	}
+
+	if err := UpsertCourseEquivalency(ctx, oldCode, newCode); err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to store course equivalency",
+		}
+	}
+
+	return &CourseEquivalency{OldCode: oldCode, NewCode: newCode}, nil
+}
@@ -0,0 +1,92 @@
+package plan
+
+import (
+	"testing"
+
+	"encore.app/transcript"
+)
+
+func TestEquivalenciesSatisfies(t *testing.T) {
+	eq := equivalencies{
+		"BLG 101E": "BLG 102E",
+		"BLG 100":  "BLG 102E", // many-to-one
+		"MAT 201":  "MAT 210",
+		"MAT 210":  "MAT 310E", // chained renumbering
+		"FIZ 101":  "FIZ 102",  // cycle
+		"FIZ 102":  "FIZ 101",
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		required string
+		want     bool
+	}{
+		{"same code", "BLG 102E", "BLG 102E", true},
+		{"renamed code", "BLG 101E", "BLG 102E", true},
+		{"second old code", "BLG100", "BLG 102E", true},
+		{"old code by base", "MAT 201E", "MAT 210", true},
+		{"chain", "MAT 201", "MAT 310E", true},
+		{"unrelated code", "BLG 103E", "BLG 102E", false},
+		{"new code doesn't satisfy old", "BLG 102E", "BLG 101E", false},
+		{"cycle terminates", "FIZ 101", "FIZ 103", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eq.satisfies(tt.code, tt.required); got != tt.want {
+				t.Errorf("satisfies(%q, %q) = %v, want %v", tt.code, tt.required, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPlanWithEquivalencies(t *testing.T) {
+	planData := PlanData{{
+		{Code: "BLG 102E"},
+		{Options: []string{"MAT 210E", "MAT 211E"}},
+	}}
+	eq := equivalencies{"BLG 101E": "BLG 102E", "MAT 201E": "MAT 210E"}
+
+	tests := []struct {
+		name    string
+		courses []transcript.Course
+		eq      equivalencies
+		want    []string // matched course code per slot, "" when unmatched
+	}{
+		{
+			"old codes satisfy new slots",
+			[]transcript.Course{{Code: "BLG 101E", Grade: "BB"}, {Code: "MAT 201E", Grade: "CC"}},
+			eq,
+			[]string{"BLG 101E", "MAT 201E"},
+		},
+		{
+			"old codes without equivalencies",
+			[]transcript.Course{{Code: "BLG 101E", Grade: "BB"}, {Code: "MAT 201E", Grade: "CC"}},
+			nil,
+			[]string{"", ""},
+		},
+		{
+			"failed old code",
+			[]transcript.Course{{Code: "BLG 101E", Grade: "FF"}},
+			eq,
+			[]string{"", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := matchPlan(planData, tt.courses, tt.eq)
+			if len(matches) != len(tt.want) {
+				t.Fatalf("got %d slot matches, want %d", len(matches), len(tt.want))
+			}
+			for i, match := range matches {
+				got := ""
+				if match.Course != nil {
+					got = match.Course.Code
+				}
+				if got != tt.want[i] {
+					t.Errorf("slot %d matched %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
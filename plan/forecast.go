@@ -0,0 +1,76 @@
+package plan
+
+import (
+	"context"
+	"math"
+
+	"encore.app/semester"
+	"encore.app/transcript"
+)
+
+// ForecastRequest represents the query for a graduation forecast
+type ForecastRequest struct {
+	// Average credits completed per semester; defaults to defaultForecastCreditsPerSemester
+	CreditsPerSemester float64 `query:"creditsPerSemester"`
+}
+
+// ForecastResponse estimates when a student will complete their plan
+type ForecastResponse struct {
+	RemainingCredits   float64 `json:"remainingCredits"`
+	CreditsPerSemester float64 `json:"creditsPerSemester"`
+	SemestersRemaining int     `json:"semestersRemaining"`
+	// LastSemester is the most recent semester on the transcript
+	LastSemester string `json:"lastSemester,omitempty"`
+	// ExpectedGraduation is empty when the transcript has no recognizable semester to count from
+	ExpectedGraduation string `json:"expectedGraduation,omitempty"`
+}
+
+//encore:api public method=GET path=/progress/:userID/forecast
+func GetGraduationForecast(ctx context.Context, userID string, req *ForecastRequest) (*ForecastResponse, error) {
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	load := req.CreditsPerSemester
+	if load <= 0 {
+		load = defaultForecastCreditsPerSemester
+	}
+
+	earned, required := creditTotals(matchPlan(plan.PlanJSON, courses, eq))
+	remaining := math.Max(required-earned, 0)
+
+	resp := &ForecastResponse{
+		RemainingCredits:   remaining,
+		CreditsPerSemester: load,
+		SemestersRemaining: int(math.Ceil(remaining / load)),
+	}
+
+	last, ok := lastSemester(courses)
+	if !ok {
+		return resp, nil
+	}
+	resp.LastSemester = last.Label()
+
+	graduation := last
+	for i := 0; i < resp.SemestersRemaining; i++ {
+		graduation = graduation.Next()
+	}
+	resp.ExpectedGraduation = graduation.Label()
+
+	return resp, nil
+}
+
+// lastSemester returns the most recent recognizable semester of the given courses
+func lastSemester(courses []transcript.Course) (semester.Key, bool) {
+	var last semester.Key
+	found := false
+	for _, course := range courses {
+		key, ok := semester.Parse(course.Semester)
+		if ok && (!found || last.Before(key)) {
+			last = key
+			found = true
+		}
+	}
+	return last, found
+}
@@ -0,0 +1,109 @@
+package plan
+
+import (
+	"context"
+	"sort"
+
+	"encore.app/transcript"
+	"encore.dev/beta/errs"
+)
+
+// impactGPAPlaces is the precision the current GPA is fetched with, so impacts
+// aren't computed from a GPA already rounded for display
+const impactGPAPlaces = 6
+
+// GPAImpactRequest represents the query for a GPA impact estimate
+type GPAImpactRequest struct {
+	// Grade assumed for each remaining course; defaults to defaultImpactGrade
+	Grade string `query:"grade"`
+}
+
+// CourseGPAImpact is how much one remaining plan course would move the CGPA
+type CourseGPAImpact struct {
+	SemesterIndex int     `json:"semesterIndex"`
+	Slot          Course  `json:"slot"`
+	ProjectedGPA  float64 `json:"projectedGpa"`
+	// Impact is ProjectedGPA minus the current GPA
+	Impact float64 `json:"impact"`
+}
+
+// GPAImpactResponse lists the remaining plan courses by how much they would
+// raise the CGPA with the assumed grade, largest first
+type GPAImpactResponse struct {
+	Grade      string            `json:"grade"`
+	CurrentGPA float64           `json:"currentGpa"`
+	GPACredits float64           `json:"gpaCredits"`
+	Courses    []CourseGPAImpact `json:"courses"`
+}
+
+//encore:api public method=GET path=/recommendations/:userID/gpa-impact
+func GetGPAImpact(ctx context.Context, userID string, req *GPAImpactRequest) (*GPAImpactResponse, error) {
+	grade := req.Grade
+	if grade == "" {
+		grade = defaultImpactGrade
+	}
+
+	points, err := gradePoints(ctx, grade)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	gpa, err := transcript.GetGPA(ctx, userID, &transcript.GPARequest{Places: impactGPAPlaces})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GPAImpactResponse{
+		Grade:      grade,
+		CurrentGPA: gpa.GPA,
+		GPACredits: gpa.TotalCredits,
+		Courses:    []CourseGPAImpact{},
+	}
+	for _, match := range matchPlan(plan.PlanJSON, courses, eq) {
+		if match.Course != nil || match.Slot.Credits <= 0 {
+			continue
+		}
+		projected := projectGPA(gpa.GPA, gpa.TotalCredits, points, match.Slot.Credits)
+		resp.Courses = append(resp.Courses, CourseGPAImpact{
+			SemesterIndex: match.SemesterIndex,
+			Slot:          match.Slot,
+			ProjectedGPA:  projected,
+			Impact:        projected - gpa.GPA,
+		})
+	}
+
+	sort.SliceStable(resp.Courses, func(i, j int) bool {
+		return resp.Courses[i].Impact > resp.Courses[j].Impact
+	})
+	return resp, nil
+}
+
+// gradePoints looks up the points of a grade in the transcript service's default scale
+func gradePoints(ctx context.Context, grade string) (float64, error) {
+	scales, err := transcript.ListGPAScales(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, scale := range scales.Scales {
+		if scale.Name != scales.Default {
+			continue
+		}
+		if points, ok := scale.Points[grade]; ok {
+			return points, nil
+		}
+	}
+	return 0, &errs.Error{
+		Code:    errs.InvalidArgument,
+		Message: "grade has no grade points: " + grade,
+	}
+}
+
+// projectGPA returns the GPA after adding a course with the given grade points
+// and credits to a GPA over gpaCredits credits
+func projectGPA(gpa, gpaCredits, points, credits float64) float64 {
+	return (gpa*gpaCredits + points*credits) / (gpaCredits + credits)
+}
@@ -0,0 +1,106 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+
+	"encore.app/coursecode"
+	"encore.app/grades"
+	"encore.app/transcript"
+)
+
+// Graduation project statuses
+const (
+	ProjectNotStarted = "not_started"
+	ProjectInProgress = "in_progress"
+	ProjectFailed     = "failed"
+	ProjectPassed     = "passed"
+)
+
+// GraduationProjectStatus reports the state of the graduation project, which
+// is a graduation requirement separate from credit counts
+type GraduationProjectStatus struct {
+	Status  string              `json:"status"`
+	Courses []transcript.Course `json:"courses"`
+}
+
+// GraduationEligibilityResponse reports whether a student meets the graduation requirements
+type GraduationEligibilityResponse struct {
+	Eligible          bool                    `json:"eligible"`
+	EarnedCredits     float64                 `json:"earnedCredits"`
+	RequiredCredits   float64                 `json:"requiredCredits"`
+	MissingMandatory  []string                `json:"missingMandatory"`
+	GraduationProject GraduationProjectStatus `json:"graduationProject"`
+	// Reasons lists the unmet requirements; empty when eligible
+	Reasons []string `json:"reasons"`
+}
+
+//encore:api public method=GET path=/progress/:userID/graduation-eligibility
+func GetGraduationEligibility(ctx context.Context, userID string) (*GraduationEligibilityResponse, error) {
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matchPlan(plan.PlanJSON, courses, eq)
+	earned, required := creditTotals(matches)
+	resp := &GraduationEligibilityResponse{
+		EarnedCredits:     earned,
+		RequiredCredits:   required,
+		MissingMandatory:  []string{},
+		GraduationProject: graduationProjectStatus(courses),
+		Reasons:           []string{},
+	}
+
+	for _, match := range matches {
+		if match.Slot.isMandatory() && match.Course == nil {
+			resp.MissingMandatory = append(resp.MissingMandatory, match.Slot.Code)
+		}
+	}
+
+	if earned < required {
+		resp.Reasons = append(resp.Reasons, fmt.Sprintf("%.1f of %.1f required credits earned", earned, required))
+	}
+	if len(resp.MissingMandatory) > 0 {
+		resp.Reasons = append(resp.Reasons, fmt.Sprintf("%d mandatory courses not passed", len(resp.MissingMandatory)))
+	}
+	if resp.GraduationProject.Status != ProjectPassed {
+		resp.Reasons = append(resp.Reasons, "graduation project not passed")
+	}
+	resp.Eligible = len(resp.Reasons) == 0
+
+	return resp, nil
+}
+
+// isGraduationProject reports whether a course is a graduation project
+func isGraduationProject(course transcript.Course) bool {
+	for _, code := range graduationProjectCodes {
+		if coursecode.Equal(course.Code, code) {
+			return true
+		}
+	}
+	return graduationProjectNamePattern.MatchString(course.Name)
+}
+
+// graduationProjectStatus summarizes the graduation project attempts on a transcript.
+// The project counts as passed once any attempt passes.
+func graduationProjectStatus(courses []transcript.Course) GraduationProjectStatus {
+	status := GraduationProjectStatus{Status: ProjectNotStarted, Courses: []transcript.Course{}}
+	for _, course := range courses {
+		if !isGraduationProject(course) {
+			continue
+		}
+		status.Courses = append(status.Courses, course)
+
+		switch {
+		case isPassed(course):
+			status.Status = ProjectPassed
+		case status.Status == ProjectPassed:
+		case course.InProgress || grades.IsInProgress(course.Grade):
+			status.Status = ProjectInProgress
+		case status.Status == ProjectNotStarted:
+			status.Status = ProjectFailed
+		}
+	}
+	return status
+}
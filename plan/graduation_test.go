@@ -0,0 +1,40 @@
+package plan
+
+import (
+	"testing"
+
+	"encore.app/transcript"
+)
+
+func TestGraduationProjectStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		courses []transcript.Course
+		want    string
+	}{
+		{"no project", []transcript.Course{{Code: "MAT 103E", Grade: "BB"}}, ProjectNotStarted},
+		{"passed", []transcript.Course{{Code: "BLG 4902E", Grade: "BA"}}, ProjectPassed},
+		{"failed", []transcript.Course{{Code: "BLG 4902E", Grade: "FF"}}, ProjectFailed},
+		{"in progress flag", []transcript.Course{{Code: "BLG 4902E", InProgress: true}}, ProjectInProgress},
+		{"in progress marker without flag", []transcript.Course{{Code: "BLG 4902E", Grade: "Devam"}}, ProjectInProgress},
+		{"no grade marker without flag", []transcript.Course{{Code: "BLG 4902E", Grade: "NG"}}, ProjectInProgress},
+		{"matched by name", []transcript.Course{{Code: "XYZ 4901", Name: "Bitirme Tasarım Projesi", Grade: "--"}}, ProjectInProgress},
+		{
+			"retake after failing",
+			[]transcript.Course{{Code: "BLG 4902E", Grade: "FF"}, {Code: "BLG 4902E", Grade: "Devam"}},
+			ProjectInProgress,
+		},
+		{
+			"passed before a later attempt",
+			[]transcript.Course{{Code: "BLG 4902E", Grade: "CC"}, {Code: "BLG 4902E", Grade: "Devam"}},
+			ProjectPassed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graduationProjectStatus(tt.courses).Status; got != tt.want {
+				t.Errorf("graduationProjectStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package plan
+
+import (
+	"strconv"
+
+	"encore.app/grades"
+	"encore.app/transcript"
+)
+
+// slotMatch pairs a plan slot with the transcript course satisfying it, if any
+type slotMatch struct {
+	SemesterIndex int
+	Slot          Course
+	Course        *transcript.Course
+}
+
+// isMandatory reports whether a plan slot names a specific course. Slots
+// without a code are elective slots satisfied by one of their options.
+func (c Course) isMandatory() bool {
+	return c.Code != ""
+}
+
+// failingGrades lists grades that don't complete a course
+var failingGrades = map[string]bool{
+	"FF": true, "VF": true,
+	"K": true, "U": true, // pass/fail courses failed
+}
+
+// isPassed reports whether a transcript course was completed successfully
+func isPassed(course transcript.Course) bool {
+	if course.Exchange && grades.IsExchangeFailure(course.Grade) {
+		return false
+	}
+	if course.InProgress || grades.IsInProgress(course.Grade) || grades.IsWithdrawn(course.Grade) {
+		return false
+	}
+	return !failingGrades[course.Grade]
+}
+
+// parseCredits converts transcript credits to a number, treating invalid values as 0
+func parseCredits(credits string) float64 {
+	f, err := strconv.ParseFloat(credits, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// slotCredits returns the credits a matched slot contributes, preferring the
+// plan's value and falling back to the transcript course's credits
+func (m slotMatch) slotCredits() float64 {
+	if m.Slot.Credits > 0 {
+		return m.Slot.Credits
+	}
+	if m.Course != nil {
+		return parseCredits(m.Course.Credits)
+	}
+	return 0
+}
+
+// slotECTS returns the ECTS a matched slot contributes, preferring the plan's
+// value and falling back to the transcript course's ECTS
+func (m slotMatch) slotECTS() float64 {
+	if m.Slot.ECTS > 0 {
+		return m.Slot.ECTS
+	}
+	if m.Course != nil {
+		return parseCredits(m.Course.ECTS)
+	}
+	return 0
+}
+
+// matchPlan assigns passed transcript courses to plan slots. Mandatory slots
+// are matched first by code, then elective slots by their options; each
+// transcript course satisfies at most one slot. Retired codes count toward
+// the slots of their replacements through eq.
+func matchPlan(planData PlanData, courses []transcript.Course, eq equivalencies) []slotMatch {
+	var matches []slotMatch
+	for i, semester := range planData {
+		for _, slot := range semester {
+			matches = append(matches, slotMatch{SemesterIndex: i, Slot: slot})
+		}
+	}
+
+	used := make([]bool, len(courses))
+	claim := func(code string) *transcript.Course {
+		for i, course := range courses {
+			if !used[i] && eq.satisfies(course.Code, code) && isPassed(course) {
+				used[i] = true
+				return &courses[i]
+			}
+		}
+		return nil
+	}
+
+	for i := range matches {
+		if matches[i].Slot.isMandatory() {
+			matches[i].Course = claim(matches[i].Slot.Code)
+		}
+	}
+
+	for i := range matches {
+		if matches[i].Slot.isMandatory() {
+			continue
+		}
+		for _, option := range matches[i].Slot.Options {
+			if course := claim(option); course != nil {
+				matches[i].Course = course
+				break
+			}
+		}
+	}
+
+	return matches
+}
+
+// ambiguousMatch is a passed transcript course that could satisfy several plan slots
+type ambiguousMatch struct {
+	Course     transcript.Course
+	Candidates []slotMatch
+}
+
+// findAmbiguousMatches returns passed transcript courses that fit more than one
+// plan slot, either as a mandatory code or as an elective option
+func findAmbiguousMatches(planData PlanData, courses []transcript.Course, eq equivalencies) []ambiguousMatch {
+	var ambiguous []ambiguousMatch
+	for _, course := range courses {
+		if !isPassed(course) {
+			continue
+		}
+
+		var candidates []slotMatch
+		for i, semester := range planData {
+			for _, slot := range semester {
+				if slotAccepts(slot, course.Code, eq) {
+					candidates = append(candidates, slotMatch{SemesterIndex: i, Slot: slot})
+				}
+			}
+		}
+
+		if len(candidates) > 1 {
+			ambiguous = append(ambiguous, ambiguousMatch{Course: course, Candidates: candidates})
+		}
+	}
+	return ambiguous
+}
+
+// slotAccepts reports whether a course code can satisfy the given plan slot
+func slotAccepts(slot Course, code string, eq equivalencies) bool {
+	if slot.isMandatory() {
+		return eq.satisfies(code, slot.Code)
+	}
+	for _, option := range slot.Options {
+		if eq.satisfies(code, option) {
+			return true
+		}
+	}
+	return false
+}
+
+// slotCategory returns the category a plan slot rolls up into
+func slotCategory(slot Course) string {
+	if slot.Category != "" {
+		return slot.Category
+	}
+	if slot.Type != "" {
+		return slot.Type
+	}
+	return uncategorizedCategory
+}
+
+// uncategorizedCategory collects passed courses that didn't fill any plan slot
+const uncategorizedCategory = "uncategorized"
+
+// unmatchedPassed returns passed transcript courses not used by any slot match
+func unmatchedPassed(courses []transcript.Course, matches []slotMatch) []transcript.Course {
+	used := make(map[*transcript.Course]bool)
+	for _, match := range matches {
+		if match.Course != nil {
+			used[match.Course] = true
+		}
+	}
+
+	var extra []transcript.Course
+	for i := range courses {
+		if !used[&courses[i]] && isPassed(courses[i]) {
+			extra = append(extra, courses[i])
+		}
+	}
+	return extra
+}
@@ -0,0 +1,92 @@
+package plan
+
+import (
+	"testing"
+
+	"encore.app/transcript"
+)
+
+func TestIsPassed(t *testing.T) {
+	tests := []struct {
+		name   string
+		course transcript.Course
+		want   bool
+	}{
+		{"letter grade", transcript.Course{Grade: "CC"}, true},
+		{"failed", transcript.Course{Grade: "FF"}, false},
+		{"pass/fail passed", transcript.Course{Grade: "G"}, true},
+		{"pass/fail satisfactory", transcript.Course{Grade: "S"}, true},
+		{"pass/fail failed", transcript.Course{Grade: "K"}, false},
+		{"pass/fail unsatisfactory", transcript.Course{Grade: "U"}, false},
+		{"in progress flag", transcript.Course{Grade: "BB", InProgress: true}, false},
+		{"in progress marker without flag", transcript.Course{Grade: "Devam"}, false},
+		{"no grade marker without flag", transcript.Course{Grade: "NG"}, false},
+		{"empty grade", transcript.Course{Grade: ""}, false},
+		{"withdrawn", transcript.Course{Grade: "W"}, false},
+		{"passed exchange", transcript.Course{Grade: "B", Exchange: true}, true},
+		{"failed exchange letter", transcript.Course{Grade: "F", Exchange: true}, false},
+		{"failed exchange pass/no-pass", transcript.Course{Grade: "NP", Exchange: true}, false},
+		{"failed exchange numeric", transcript.Course{Grade: "5,0", Exchange: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPassed(tt.course); got != tt.want {
+				t.Errorf("isPassed(%+v) = %v, want %v", tt.course, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlotAccepts(t *testing.T) {
+	tests := []struct {
+		name string
+		slot Course
+		code string
+		want bool
+	}{
+		{"mandatory exact", Course{Code: "BLG 102E"}, "BLG 102E", true},
+		{"mandatory without space", Course{Code: "BLG 102E"}, "BLG102E", true},
+		{"mandatory lower case", Course{Code: "BLG102E"}, "blg 102e", true},
+		{"mandatory other course", Course{Code: "BLG 102E"}, "BLG 103E", false},
+		{"elective option without space", Course{Options: []string{"MAT 201E", "MAT 210E"}}, "MAT210E", true},
+		{"elective not an option", Course{Options: []string{"MAT 201E"}}, "MAT 210E", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slotAccepts(tt.slot, tt.code, nil); got != tt.want {
+				t.Errorf("slotAccepts(%+v, %q) = %v, want %v", tt.slot, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPlanSuffixStripped(t *testing.T) {
+	planData := PlanData{{
+		{Code: "BLG 102E"},
+		{Code: "FIZ 101EL"},
+		{Options: []string{"MAT 210E"}},
+	}}
+
+	tests := []struct {
+		name   string
+		course transcript.Course
+		want   int // index of the slot the course fills, -1 for none
+	}{
+		{"suffix stripped by the parser", transcript.Course{Code: "BLG 102", Grade: "BB"}, 0},
+		{"same suffix", transcript.Course{Code: "BLG 102E", Grade: "BB"}, 0},
+		{"other language section", transcript.Course{Code: "BLG 102T", Grade: "BB"}, -1},
+		{"stripped lab code", transcript.Course{Code: "FIZ 101L", Grade: "AA"}, 1},
+		{"stripped elective option", transcript.Course{Code: "MAT210", Grade: "CC"}, 2},
+		{"different number", transcript.Course{Code: "BLG 103", Grade: "BB"}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := matchPlan(planData, []transcript.Course{tt.course}, nil)
+			for i, match := range matches {
+				if filled := match.Course != nil; filled != (i == tt.want) {
+					t.Errorf("slot %d (%+v) filled = %v, want %v", i, match.Slot, filled, i == tt.want)
+				}
+			}
+		})
+	}
+}
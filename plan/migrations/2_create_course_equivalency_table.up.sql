@@ -0,0 +1,6 @@
+-- Map retired course codes to the codes that replaced them after curriculum changes
+CREATE TABLE course_equivalency (
+    old_code TEXT PRIMARY KEY,
+    new_code TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
@@ -45,8 +45,7 @@ func GetPlanByUserID(ctx context.Context, userID string) (*Plan, error) {
 	}
 
 	// Parse the plan JSON
-	var planJSON PlanData
-	err = json.Unmarshal(planJSONBytes, &planJSON)
+	planJSON, err := decodeStoredPlan(planJSONBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +112,7 @@ func GetAllPlans(ctx context.Context) ([]Plan, error) {
 	}
 	defer rows.Close()
 
-	var plans []Plan
+	plans := []Plan{}
 	for rows.Next() {
 		var plan Plan
 		var planJSONBytes []byte
@@ -124,8 +123,7 @@ func GetAllPlans(ctx context.Context) ([]Plan, error) {
 		}
 
 		// Parse the plan JSON
-		var planJSON PlanData
-		err = json.Unmarshal(planJSONBytes, &planJSON)
+		planJSON, err := decodeStoredPlan(planJSONBytes)
 		if err != nil {
 			return nil, err
 		}
@@ -139,4 +137,43 @@ func GetAllPlans(ctx context.Context) ([]Plan, error) {
 	}
 
 	return plans, nil
-} 
\ No newline at end of file
+}
+
+// UpsertCourseEquivalency maps an old course code to the code that replaced it
+func UpsertCourseEquivalency(ctx context.Context, oldCode, newCode string) error {
+	_, err := plandb.Exec(ctx, `
+		INSERT INTO course_equivalency (old_code, new_code)
+		VALUES ($1, $2)
+		ON CONFLICT (old_code)
+		DO UPDATE SET new_code = $2
+	`, oldCode, newCode)
+
+	return err
+}
+
+// GetCourseEquivalencies retrieves every stored old code -> new code mapping
+func GetCourseEquivalencies(ctx context.Context) (map[string]string, error) {
+	rows, err := plandb.Query(ctx, `
+		SELECT old_code, new_code
+		FROM course_equivalency
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mapping := make(map[string]string)
+	for rows.Next() {
+		var oldCode, newCode string
+		if err := rows.Scan(&oldCode, &newCode); err != nil {
+			return nil, err
+		}
+		mapping[oldCode] = newCode
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
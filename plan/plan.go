@@ -4,18 +4,26 @@ package plan
 import (
 	"context"
 	"fmt"
+
+	"encore.dev/beta/errs"
 )
 
 // StorePlanRequest represents the request body for storing a plan
 type StorePlanRequest struct {
 	UserID   string    `json:"userId"`
 	PlanJSON PlanData  `json:"planJson"`
+	// RecomputeProgress returns the progress against the just-saved plan
+	RecomputeProgress bool `json:"recomputeProgress,omitempty"`
 }
 
 // StorePlanResponse represents the response for storing a plan
 type StorePlanResponse struct {
 	Success bool   `json:"success"`
 	Error   string `json:"error,omitempty"`
+	// Progress is set when recomputeProgress was requested and could be computed
+	Progress *ProgressPercentageResponse `json:"progress,omitempty"`
+	// ProgressError explains why a requested progress recomputation is missing
+	ProgressError string `json:"progressError,omitempty"`
 }
 
 // GetPlanRequest represents the request for getting a plan
@@ -49,6 +57,10 @@ func StorePlan(ctx context.Context, req *StorePlanRequest) (*StorePlanResponse,
 		}, nil
 	}
 
+	if err := validatePlan(req.PlanJSON); err != nil {
+		return nil, err
+	}
+
 	err := InsertPlan(ctx, req.UserID, req.PlanJSON)
 	if err != nil {
 		return &StorePlanResponse{
@@ -57,9 +69,22 @@ func StorePlan(ctx context.Context, req *StorePlanRequest) (*StorePlanResponse,
 		}, nil
 	}
 
-	return &StorePlanResponse{
+	resp := &StorePlanResponse{
 		Success: true,
-	}, nil
+	}
+	if req.RecomputeProgress {
+		progress, err := GetProgressPercentage(ctx, req.UserID)
+		switch {
+		case errs.Code(err) == errs.NotFound:
+			resp.ProgressError = "no transcript stored yet; progress will be available after uploading one"
+		case err != nil:
+			resp.ProgressError = fmt.Sprintf("Failed to compute progress: %v", err)
+		default:
+			resp.Progress = progress
+		}
+	}
+
+	return resp, nil
 }
 
 //encore:api public method=POST path=/get-plan
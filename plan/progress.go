@@ -0,0 +1,325 @@
+package plan
+
+import (
+	"context"
+	"math"
+
+	"encore.app/transcript"
+	"encore.dev/beta/errs"
+)
+
+// ProgressPercentageResponse represents a student's overall degree completion
+type ProgressPercentageResponse struct {
+	Percentage      float64 `json:"percentage"`
+	EarnedCredits   float64 `json:"earnedCredits"`
+	RequiredCredits float64 `json:"requiredCredits"`
+	// CreditMismatches lists matched courses whose parsed credits differ from
+	// the plan, which usually points at a parse error
+	CreditMismatches []CreditMismatch `json:"creditMismatches,omitempty"`
+}
+
+// CreditMismatch is a matched course whose transcript credits disagree with its plan slot
+type CreditMismatch struct {
+	Course          transcript.Course `json:"course"`
+	ParsedCredits   float64           `json:"parsedCredits"`
+	ExpectedCredits float64           `json:"expectedCredits"`
+}
+
+//encore:api public method=GET path=/progress/:userID/percentage
+func GetProgressPercentage(ctx context.Context, userID string) (*ProgressPercentageResponse, error) {
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matchPlan(plan.PlanJSON, courses, eq)
+	earned, required := creditTotals(matches)
+
+	var percentage float64
+	if required > 0 {
+		percentage = earned / required * 100
+	}
+	// Extra counting courses must not push the bar past completion
+	if percentage > 100 {
+		percentage = 100
+	}
+
+	return &ProgressPercentageResponse{
+		Percentage:       percentage,
+		EarnedCredits:    earned,
+		RequiredCredits:  required,
+		CreditMismatches: creditMismatches(matches),
+	}, nil
+}
+
+// creditMismatches returns the matched courses whose parsed credits differ
+// from the credits their plan slot expects
+func creditMismatches(matches []slotMatch) []CreditMismatch {
+	var mismatches []CreditMismatch
+	for _, match := range matches {
+		if match.Course == nil || match.Slot.Credits <= 0 {
+			continue
+		}
+		parsed := parseCredits(match.Course.Credits)
+		if math.Abs(parsed-match.Slot.Credits) < creditMismatchTolerance {
+			continue
+		}
+		mismatches = append(mismatches, CreditMismatch{
+			Course:          *match.Course,
+			ParsedCredits:   parsed,
+			ExpectedCredits: match.Slot.Credits,
+		})
+	}
+	return mismatches
+}
+
+// creditTotals sums the credits earned toward and required by the matched plan
+// slots. Both use slotCredits, so a filled slot without plan credits requires
+// what it earns instead of completing more than is required.
+func creditTotals(matches []slotMatch) (earned, required float64) {
+	for _, match := range matches {
+		required += match.slotCredits()
+		if match.Course != nil {
+			earned += match.slotCredits()
+		}
+	}
+	return earned, required
+}
+
+// CandidateSlot is a plan slot a course could be counted toward
+type CandidateSlot struct {
+	SemesterIndex int    `json:"semesterIndex"`
+	Slot          Course `json:"slot"`
+}
+
+// AmbiguousMatch is a completed course that fits more than one plan slot
+type AmbiguousMatch struct {
+	Course     transcript.Course `json:"course"`
+	Candidates []CandidateSlot   `json:"candidates"`
+}
+
+// AmbiguousMatchesResponse lists courses advisors may need to assign manually
+type AmbiguousMatchesResponse struct {
+	Matches []AmbiguousMatch `json:"matches"`
+}
+
+//encore:api public method=GET path=/progress/:userID/ambiguous-matches
+func GetAmbiguousMatches(ctx context.Context, userID string) (*AmbiguousMatchesResponse, error) {
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &AmbiguousMatchesResponse{Matches: []AmbiguousMatch{}}
+	for _, match := range findAmbiguousMatches(plan.PlanJSON, courses, eq) {
+		candidates := make([]CandidateSlot, 0, len(match.Candidates))
+		for _, candidate := range match.Candidates {
+			candidates = append(candidates, CandidateSlot{
+				SemesterIndex: candidate.SemesterIndex,
+				Slot:          candidate.Slot,
+			})
+		}
+		resp.Matches = append(resp.Matches, AmbiguousMatch{
+			Course:     match.Course,
+			Candidates: candidates,
+		})
+	}
+
+	return resp, nil
+}
+
+// CategoryBreakdown is the credit rollup of one plan category
+type CategoryBreakdown struct {
+	Category        string              `json:"category"`
+	EarnedCredits   float64             `json:"earnedCredits"`
+	RequiredCredits float64             `json:"requiredCredits"`
+	Courses         []transcript.Course `json:"courses"`
+}
+
+// CategoryBreakdownResponse represents the per-category degree audit
+type CategoryBreakdownResponse struct {
+	Categories []CategoryBreakdown `json:"categories"`
+}
+
+//encore:api public method=GET path=/progress/:userID/category-breakdown
+func GetCategoryBreakdown(ctx context.Context, userID string) (*CategoryBreakdownResponse, error) {
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matchPlan(plan.PlanJSON, courses, eq)
+	return &CategoryBreakdownResponse{Categories: breakdownCategories(courses, matches)}, nil
+}
+
+// breakdownCategories rolls plan matches up into categories, in the order they
+// first appear in the plan. Passed courses that filled no slot are reported as
+// uncategorized.
+func breakdownCategories(courses []transcript.Course, matches []slotMatch) []CategoryBreakdown {
+	index := make(map[string]int)
+	categories := []CategoryBreakdown{}
+	bucket := func(category string) *CategoryBreakdown {
+		i, ok := index[category]
+		if !ok {
+			i = len(categories)
+			index[category] = i
+			categories = append(categories, CategoryBreakdown{
+				Category: category,
+				Courses:  []transcript.Course{},
+			})
+		}
+		return &categories[i]
+	}
+
+	for _, match := range matches {
+		b := bucket(slotCategory(match.Slot))
+		b.RequiredCredits += match.slotCredits()
+		if match.Course != nil {
+			b.EarnedCredits += match.slotCredits()
+			b.Courses = append(b.Courses, *match.Course)
+		}
+	}
+
+	for _, course := range unmatchedPassed(courses, matches) {
+		b := bucket(uncategorizedCategory)
+		b.EarnedCredits += parseCredits(course.Credits)
+		b.Courses = append(b.Courses, course)
+	}
+
+	return categories
+}
+
+// loadProgressInputs loads the plan, transcript courses and course equivalencies
+// a progress computation needs
+func loadProgressInputs(ctx context.Context, userID string) (*Plan, []transcript.Course, equivalencies, error) {
+	if userID == "" {
+		return nil, nil, nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "userId is required",
+		}
+	}
+
+	plan, err := GetPlanByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve plan",
+		}
+	}
+	if plan == nil {
+		return nil, nil, nil, &errs.Error{
+			Code:    errs.NotFound,
+			Message: "plan not found",
+		}
+	}
+
+	resp, err := transcript.GetTranscript(ctx, userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	eq, err := loadEquivalencies(ctx)
+	if err != nil {
+		return nil, nil, nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve course equivalencies",
+		}
+	}
+
+	return plan, resp.Transcript.Courses, eq, nil
+}
+
+// CategoryECTS is the ECTS rollup of one plan category
+type CategoryECTS struct {
+	Category     string  `json:"category"`
+	EarnedECTS   float64 `json:"earnedEcts"`
+	RequiredECTS float64 `json:"requiredEcts"`
+}
+
+// ECTSProgressResponse represents degree completion measured in ECTS
+type ECTSProgressResponse struct {
+	EarnedECTS   float64        `json:"earnedEcts"`
+	RequiredECTS float64        `json:"requiredEcts"`
+	Categories   []CategoryECTS `json:"categories"`
+}
+
+//encore:api public method=GET path=/progress/:userID/ects
+func GetECTSProgress(ctx context.Context, userID string) (*ECTSProgressResponse, error) {
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matchPlan(plan.PlanJSON, courses, eq)
+
+	// Categories are reported in the order they first appear in the plan
+	index := make(map[string]int)
+	resp := &ECTSProgressResponse{Categories: []CategoryECTS{}}
+	bucket := func(category string) *CategoryECTS {
+		i, ok := index[category]
+		if !ok {
+			i = len(resp.Categories)
+			index[category] = i
+			resp.Categories = append(resp.Categories, CategoryECTS{Category: category})
+		}
+		return &resp.Categories[i]
+	}
+
+	for _, match := range matches {
+		b := bucket(slotCategory(match.Slot))
+		b.RequiredECTS += match.slotECTS()
+		resp.RequiredECTS += match.slotECTS()
+		if match.Course != nil {
+			b.EarnedECTS += match.slotECTS()
+			resp.EarnedECTS += match.slotECTS()
+		}
+	}
+
+	for _, course := range unmatchedPassed(courses, matches) {
+		ects := parseCredits(course.ECTS)
+		bucket(uncategorizedCategory).EarnedECTS += ects
+		resp.EarnedECTS += ects
+	}
+
+	return resp, nil
+}
+
+// CountingCourse is a transcript course counted toward a plan slot
+type CountingCourse struct {
+	Course        transcript.Course `json:"course"`
+	SemesterIndex int               `json:"semesterIndex"`
+	Slot          Course            `json:"slot"`
+	Category      string            `json:"category"`
+}
+
+// CountingCoursesResponse lists the courses that count toward the degree
+type CountingCoursesResponse struct {
+	Courses []CountingCourse `json:"courses"`
+}
+
+// GetCountingCourses returns the transcript courses matched to plan slots, as
+// listed by the graduation audit. Passed courses missing here are the ones
+// unmatchedPassed reports as extra.
+//
+//encore:api public method=GET path=/progress/:userID/counting-courses
+func GetCountingCourses(ctx context.Context, userID string) (*CountingCoursesResponse, error) {
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &CountingCoursesResponse{Courses: []CountingCourse{}}
+	for _, match := range matchPlan(plan.PlanJSON, courses, eq) {
+		if match.Course == nil {
+			continue
+		}
+		resp.Courses = append(resp.Courses, CountingCourse{
+			Course:        *match.Course,
+			SemesterIndex: match.SemesterIndex,
+			Slot:          match.Slot,
+			Category:      slotCategory(match.Slot),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,100 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+
+	"encore.app/transcript"
+)
+
+func TestCreditMismatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		slot   Course
+		course *transcript.Course
+		want   []CreditMismatch
+	}{
+		{
+			"parsed 0 but plan expects 3",
+			Course{Code: "BLG 102E", Credits: 3},
+			&transcript.Course{Code: "BLG 102E", Credits: "0", Grade: "BB"},
+			[]CreditMismatch{{Course: transcript.Course{Code: "BLG 102E", Credits: "0", Grade: "BB"}, ParsedCredits: 0, ExpectedCredits: 3}},
+		},
+		{
+			"decimal credits parsed as whole",
+			Course{Code: "FIZ 101EL", Credits: 1.5},
+			&transcript.Course{Code: "FIZ 101EL", Credits: "15", Grade: "AA"},
+			[]CreditMismatch{{Course: transcript.Course{Code: "FIZ 101EL", Credits: "15", Grade: "AA"}, ParsedCredits: 15, ExpectedCredits: 1.5}},
+		},
+		{
+			"credits agree",
+			Course{Code: "BLG 102E", Credits: 3},
+			&transcript.Course{Code: "BLG 102E", Credits: "3", Grade: "BB"},
+			nil,
+		},
+		{
+			"unmatched slot",
+			Course{Code: "BLG 102E", Credits: 3},
+			nil,
+			nil,
+		},
+		{
+			"plan without credits",
+			Course{Code: "BLG 102E"},
+			&transcript.Course{Code: "BLG 102E", Credits: "3", Grade: "BB"},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := creditMismatches([]slotMatch{{Slot: tt.slot, Course: tt.course}})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("creditMismatches() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreditTotals(t *testing.T) {
+	tests := []struct {
+		name         string
+		matches      []slotMatch
+		wantEarned   float64
+		wantRequired float64
+	}{
+		{
+			"plan credits",
+			[]slotMatch{
+				{Slot: Course{Code: "BLG 101E", Credits: 3}, Course: &transcript.Course{Code: "BLG 101E", Credits: "3", Grade: "BB"}},
+				{Slot: Course{Code: "MAT 103E", Credits: 4}},
+			},
+			3, 7,
+		},
+		{
+			"filled slot without plan credits",
+			[]slotMatch{
+				{Slot: Course{Type: "elective", Options: []string{"HSS 201"}}, Course: &transcript.Course{Code: "HSS 201", Credits: "3", Grade: "AA"}},
+				{Slot: Course{Code: "MAT 103E", Credits: 4}},
+			},
+			3, 7,
+		},
+		{
+			"open slot without plan credits",
+			[]slotMatch{
+				{Slot: Course{Type: "elective", Options: []string{"HSS 201"}}},
+			},
+			0, 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			earned, required := creditTotals(tt.matches)
+			if earned != tt.wantEarned || required != tt.wantRequired {
+				t.Errorf("creditTotals() = %v, %v, want %v, %v", earned, required, tt.wantEarned, tt.wantRequired)
+			}
+			if earned > required {
+				t.Errorf("earned %v exceeds required %v", earned, required)
+			}
+		})
+	}
+}
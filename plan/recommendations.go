@@ -0,0 +1,125 @@
+package plan
+
+import (
+	"context"
+
+	"encore.app/coursecode"
+	"encore.app/grades"
+	"encore.app/transcript"
+)
+
+// NextCoursesRequest represents the query for next-semester recommendations
+type NextCoursesRequest struct {
+	// Maximum total local credits to recommend; defaults to defaultNextSemesterCredits
+	MaxCredits float64 `query:"maxCredits"`
+}
+
+// RecommendedCourse is a plan slot suggested for the next semester
+type RecommendedCourse struct {
+	SemesterIndex int    `json:"semesterIndex"`
+	Course        Course `json:"course"`
+}
+
+// NextCoursesResponse represents the recommended courses for the next semester
+type NextCoursesResponse struct {
+	Courses      []RecommendedCourse `json:"courses"`
+	TotalCredits float64             `json:"totalCredits"`
+}
+
+//encore:api public method=GET path=/recommendations/:userID/next-courses
+func GetNextCourses(ctx context.Context, userID string, req *NextCoursesRequest) (*NextCoursesResponse, error) {
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxCredits := req.MaxCredits
+	if maxCredits <= 0 {
+		maxCredits = defaultNextSemesterCredits
+	}
+
+	return recommendNextCourses(plan.PlanJSON, courses, eq, maxCredits), nil
+}
+
+// recommendNextCourses picks the open plan slots whose prerequisites are
+// passed, in plan order, until their credits reach maxCredits. Slots an
+// in-progress course will fill are left out.
+func recommendNextCourses(planData PlanData, courses []transcript.Course, eq equivalencies, maxCredits float64) *NextCoursesResponse {
+	passed := make(map[string]bool)
+	for _, course := range courses {
+		if !isPassed(course) {
+			continue
+		}
+		// A passed retired course also counts as a prerequisite under its replacement codes
+		code := coursecode.Normalize(course.Code)
+		for i := 0; i <= len(eq) && code != "" && !passed[code]; i++ {
+			passed[code] = true
+			passed[coursecode.Base(code)] = true
+			code = eq[code]
+		}
+	}
+
+	matches := matchPlan(planData, courses, eq)
+	covered := coveredByInProgress(matches, courses, eq)
+
+	// Matches are produced in plan order, so earlier intended semesters come first
+	resp := &NextCoursesResponse{Courses: []RecommendedCourse{}}
+	for i, match := range matches {
+		if match.Course != nil || covered[i] || !prerequisitesMet(match.Slot, passed) {
+			continue
+		}
+		// A full budget also shuts out slots without credits
+		if resp.TotalCredits >= maxCredits || resp.TotalCredits+match.Slot.Credits > maxCredits {
+			continue
+		}
+
+		resp.Courses = append(resp.Courses, RecommendedCourse{
+			SemesterIndex: match.SemesterIndex,
+			Course:        match.Slot,
+		})
+		resp.TotalCredits += match.Slot.Credits
+	}
+
+	return resp
+}
+
+// coveredByInProgress returns the indices of open slots that in-progress courses
+// will fill once graded. Each course covers one slot, preferring a mandatory
+// slot for its code over an elective option.
+func coveredByInProgress(matches []slotMatch, courses []transcript.Course, eq equivalencies) map[int]bool {
+	covered := make(map[int]bool)
+	for _, course := range courses {
+		if !course.InProgress && !grades.IsInProgress(course.Grade) {
+			continue
+		}
+
+		slot := -1
+		for i, match := range matches {
+			if match.Course != nil || covered[i] || !slotAccepts(match.Slot, course.Code, eq) {
+				continue
+			}
+			if match.Slot.isMandatory() {
+				slot = i
+				break
+			}
+			if slot < 0 {
+				slot = i
+			}
+		}
+		if slot >= 0 {
+			covered[slot] = true
+		}
+	}
+	return covered
+}
+
+// prerequisitesMet reports whether every known prerequisite of slot has been passed
+func prerequisitesMet(slot Course, passed map[string]bool) bool {
+	for _, prereq := range slot.Prerequisites {
+		// A suffix-stripped transcript code still satisfies a suffixed prerequisite
+		if !passed[coursecode.Normalize(prereq)] && !passed[coursecode.Base(prereq)] {
+			return false
+		}
+	}
+	return true
+}
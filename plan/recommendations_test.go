@@ -0,0 +1,116 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+
+	"encore.app/transcript"
+)
+
+func TestPrerequisitesMet(t *testing.T) {
+	tests := []struct {
+		name   string
+		prereq []string
+		passed map[string]bool
+		want   bool
+	}{
+		{"no prerequisites", nil, nil, true},
+		{"passed with suffix", []string{"BLG 102E"}, map[string]bool{"BLG 102E": true, "BLG 102": true}, true},
+		{"passed with suffix stripped", []string{"BLG 102E"}, map[string]bool{"BLG 102": true}, true},
+		{"prerequisite written without space", []string{"BLG102E"}, map[string]bool{"BLG 102": true}, true},
+		{"one of two missing", []string{"BLG 102E", "MAT 103E"}, map[string]bool{"BLG 102": true}, false},
+		{"not passed", []string{"BLG 102E"}, map[string]bool{"BLG 103": true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prerequisitesMet(Course{Prerequisites: tt.prereq}, tt.passed); got != tt.want {
+				t.Errorf("prerequisitesMet(%q) = %v, want %v", tt.prereq, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecommendNextCourses(t *testing.T) {
+	planData := PlanData{
+		{
+			{Type: "mandatory", Code: "BLG 101E", Credits: 3},
+			{Type: "mandatory", Code: "MAT 103E", Credits: 4},
+		},
+		{
+			{Type: "mandatory", Code: "BLG 102E", Credits: 3, Prerequisites: []string{"BLG 101E"}},
+			{Type: "mandatory", Code: "STJ 300", Credits: 0},
+			{Type: "elective", Options: []string{"HSS 201", "HSS 202"}, Credits: 3},
+		},
+	}
+	tests := []struct {
+		name       string
+		courses    []transcript.Course
+		maxCredits float64
+		want       []string
+	}{
+		{
+			name:       "open slots within the budget",
+			courses:    []transcript.Course{{Code: "BLG 101E", Grade: "BB"}},
+			maxCredits: 21,
+			want:       []string{"MAT 103E", "BLG 102E", "STJ 300", "HSS 201"},
+		},
+		{
+			name:       "prerequisite not passed",
+			courses:    nil,
+			maxCredits: 21,
+			want:       []string{"BLG 101E", "MAT 103E", "STJ 300", "HSS 201"},
+		},
+		{
+			name:       "full budget shuts out zero-credit slots",
+			courses:    []transcript.Course{{Code: "BLG 101E", Grade: "BB"}},
+			maxCredits: 7,
+			want:       []string{"MAT 103E", "BLG 102E"},
+		},
+		{
+			name: "in-progress flag covers its slot",
+			courses: []transcript.Course{
+				{Code: "BLG 101E", Grade: "BB"},
+				{Code: "MAT 103E", InProgress: true},
+			},
+			maxCredits: 21,
+			want:       []string{"BLG 102E", "STJ 300", "HSS 201"},
+		},
+		{
+			name: "in-progress marker covers its slot",
+			courses: []transcript.Course{
+				{Code: "BLG 101E", Grade: "BB"},
+				{Code: "MAT 103E", Grade: "Devam"},
+			},
+			maxCredits: 21,
+			want:       []string{"BLG 102E", "STJ 300", "HSS 201"},
+		},
+		{
+			name: "in-progress elective option covers the elective slot",
+			courses: []transcript.Course{
+				{Code: "BLG 101E", Grade: "BB"},
+				{Code: "HSS 202", Grade: "NG"},
+			},
+			maxCredits: 21,
+			want:       []string{"MAT 103E", "BLG 102E", "STJ 300"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := recommendNextCourses(planData, tt.courses, nil, tt.maxCredits)
+			got := []string{}
+			for _, course := range resp.Courses {
+				code := course.Course.Code
+				if code == "" {
+					code = course.Course.Options[0]
+				}
+				got = append(got, code)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("recommended %v, want %v", got, tt.want)
+			}
+			if resp.TotalCredits > tt.maxCredits {
+				t.Errorf("TotalCredits = %v, exceeds %v", resp.TotalCredits, tt.maxCredits)
+			}
+		})
+	}
+}
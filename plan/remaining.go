@@ -0,0 +1,135 @@
+package plan
+
+import (
+	"context"
+	"math"
+
+	"encore.app/transcript"
+)
+
+// RemainingSlot is a plan slot the student still has to complete
+type RemainingSlot struct {
+	SemesterIndex int    `json:"semesterIndex"`
+	Slot          Course `json:"slot"`
+	// Options lists the elective options not yet passed; empty for mandatory slots
+	Options []string `json:"options,omitempty"`
+	// Reassignable lists completed courses counted toward another slot that could
+	// fill this one instead; an advisor may move them to close this slot
+	Reassignable []transcript.Course `json:"reassignable,omitempty"`
+}
+
+// MinimumRemainingResponse lists the specific slots still standing between a
+// student and graduation
+type MinimumRemainingResponse struct {
+	Required         []RemainingSlot `json:"required"`
+	Electives        []RemainingSlot `json:"electives"`
+	RemainingCredits float64         `json:"remainingCredits"`
+}
+
+//encore:api public method=GET path=/progress/:userID/minimum-remaining
+func GetMinimumRemaining(ctx context.Context, userID string) (*MinimumRemainingResponse, error) {
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ambiguous := findAmbiguousMatches(plan.PlanJSON, courses, eq)
+
+	resp := &MinimumRemainingResponse{
+		Required:  []RemainingSlot{},
+		Electives: []RemainingSlot{},
+	}
+	for _, match := range matchPlan(plan.PlanJSON, courses, eq) {
+		if match.Course != nil {
+			continue
+		}
+
+		remaining := RemainingSlot{
+			SemesterIndex: match.SemesterIndex,
+			Slot:          match.Slot,
+		}
+		for _, candidate := range ambiguous {
+			if slotAccepts(match.Slot, candidate.Course.Code, eq) {
+				remaining.Reassignable = append(remaining.Reassignable, candidate.Course)
+			}
+		}
+		resp.RemainingCredits += match.Slot.Credits
+
+		if match.Slot.isMandatory() {
+			resp.Required = append(resp.Required, remaining)
+			continue
+		}
+		remaining.Options = untakenOptions(match.Slot, courses, eq)
+		resp.Electives = append(resp.Electives, remaining)
+	}
+
+	return resp, nil
+}
+
+// CategoryRemaining is what a student still needs in one plan category
+type CategoryRemaining struct {
+	Category string `json:"category"`
+	// RemainingCredits is zero once the category is satisfied, even if over-satisfied
+	RemainingCredits float64 `json:"remainingCredits"`
+	// OpenElectives lists the unfilled elective slots with their untaken options
+	OpenElectives []RemainingSlot `json:"openElectives"`
+}
+
+// RemainingByCategoryResponse is the per-category registration worklist
+type RemainingByCategoryResponse struct {
+	Categories []CategoryRemaining `json:"categories"`
+}
+
+//encore:api public method=GET path=/progress/:userID/remaining-by-category
+func GetRemainingByCategory(ctx context.Context, userID string) (*RemainingByCategoryResponse, error) {
+	plan, courses, eq, err := loadProgressInputs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matchPlan(plan.PlanJSON, courses, eq)
+
+	resp := &RemainingByCategoryResponse{Categories: []CategoryRemaining{}}
+	index := make(map[string]int)
+	for _, breakdown := range breakdownCategories(courses, matches) {
+		index[breakdown.Category] = len(resp.Categories)
+		resp.Categories = append(resp.Categories, CategoryRemaining{
+			Category:         breakdown.Category,
+			RemainingCredits: math.Max(breakdown.RequiredCredits-breakdown.EarnedCredits, 0),
+			OpenElectives:    []RemainingSlot{},
+		})
+	}
+
+	for _, match := range matches {
+		if match.Course != nil || match.Slot.isMandatory() {
+			continue
+		}
+		category := &resp.Categories[index[slotCategory(match.Slot)]]
+		category.OpenElectives = append(category.OpenElectives, RemainingSlot{
+			SemesterIndex: match.SemesterIndex,
+			Slot:          match.Slot,
+			Options:       untakenOptions(match.Slot, courses, eq),
+		})
+	}
+
+	return resp, nil
+}
+
+// untakenOptions returns the options of an elective slot the student hasn't passed.
+// Passed options were already claimed by other slots during matching.
+func untakenOptions(slot Course, courses []transcript.Course, eq equivalencies) []string {
+	var options []string
+	for _, option := range slot.Options {
+		taken := false
+		for _, course := range courses {
+			if isPassed(course) && eq.satisfies(course.Code, option) {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			options = append(options, option)
+		}
+	}
+	return options
+}
@@ -0,0 +1,108 @@
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"encore.dev/beta/errs"
+)
+
+// UnmarshalJSON decodes a plan after checking its nesting, so a flat course list
+// or a semester holding something other than course objects is rejected with
+// the offending index instead of decoding into an odd plan
+func (p *PlanData) UnmarshalJSON(data []byte) error {
+	if jsonKind(data) == "null" {
+		*p = nil
+		return nil
+	}
+
+	var semesters []json.RawMessage
+	if err := json.Unmarshal(data, &semesters); err != nil {
+		return fmt.Errorf("planJson must be an array of semesters, got %s", jsonKind(data))
+	}
+
+	plan := make(PlanData, len(semesters))
+	for i, semesterJSON := range semesters {
+		var courses []json.RawMessage
+		if kind := jsonKind(semesterJSON); kind != "array" {
+			if kind == "object" {
+				return fmt.Errorf("planJson[%d] must be an array of courses, got object; wrap the courses of each semester in an array", i)
+			}
+			return fmt.Errorf("planJson[%d] must be an array of courses, got %s", i, kind)
+		}
+		if err := json.Unmarshal(semesterJSON, &courses); err != nil {
+			return fmt.Errorf("planJson[%d]: %v", i, err)
+		}
+
+		plan[i] = make([]Course, len(courses))
+		for j, courseJSON := range courses {
+			if kind := jsonKind(courseJSON); kind != "object" {
+				return fmt.Errorf("planJson[%d][%d] must be a course object, got %s", i, j, kind)
+			}
+			if err := json.Unmarshal(courseJSON, &plan[i][j]); err != nil {
+				return fmt.Errorf("planJson[%d][%d]: %v", i, j, err)
+			}
+		}
+	}
+
+	*p = plan
+	return nil
+}
+
+// decodeStoredPlan decodes a plan read from the database. The nesting checks of
+// UnmarshalJSON are for client input; rows stored before them, such as a
+// legacy [null], must still load, so stored plans are decoded without them.
+func decodeStoredPlan(data []byte) (PlanData, error) {
+	var semesters [][]Course
+	if err := json.Unmarshal(data, &semesters); err != nil {
+		return nil, err
+	}
+	return PlanData(semesters), nil
+}
+
+// jsonKind names the kind of a JSON value for error messages
+func jsonKind(data []byte) string {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return "nothing"
+	}
+	switch data[0] {
+	case '[':
+		return "array"
+	case '{':
+		return "object"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
+
+// validatePlan checks the courses of a decoded plan, pointing at the first
+// offending semester and course
+func validatePlan(plan PlanData) error {
+	for i, semester := range plan {
+		for j, course := range semester {
+			var problem string
+			switch {
+			case course.Code == "" && len(course.Options) == 0 && course.Type == "" && course.Category == "":
+				problem = "must have a code, options, type or category"
+			case course.Credits < 0:
+				problem = "credits cannot be negative"
+			case course.ECTS < 0:
+				problem = "ects cannot be negative"
+			}
+			if problem != "" {
+				return &errs.Error{
+					Code:    errs.InvalidArgument,
+					Message: fmt.Sprintf("planJson[%d][%d] %s", i, j, problem),
+				}
+			}
+		}
+	}
+	return nil
+}
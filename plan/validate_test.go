@@ -0,0 +1,63 @@
+package plan
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPlanDataUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"nested plan", `[[{"type":"core","code":"BLG 101E"}],[]]`, ""},
+		{"null plan", `null`, ""},
+		{"flat course list", `[{"type":"core","code":"BLG 101E"}]`, "planJson[0] must be an array of courses, got object"},
+		{"semester with a non-object", `[[{"type":"core"}, "BLG 101E"]]`, "planJson[0][1] must be a course object, got string"},
+		{"not an array", `{"semesters":[]}`, "planJson must be an array of semesters, got object"},
+		{"legacy null semester", `[null]`, "planJson[0] must be an array of courses, got null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var plan PlanData
+			err := json.Unmarshal([]byte(tt.input), &plan)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Unmarshal(%s) error = %v", tt.input, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Unmarshal(%s) error = %v, want %q", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeStoredPlan(t *testing.T) {
+	tests := []struct {
+		name          string
+		stored        string
+		wantSemesters int
+		wantErr       bool
+	}{
+		{"nested plan", `[[{"type":"core","code":"BLG 101E"}],[]]`, 2, false},
+		{"legacy null semester", `[null]`, 1, false},
+		{"legacy null course", `[[null]]`, 1, false},
+		{"null plan", `null`, 0, false},
+		{"not JSON", `{`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := decodeStoredPlan([]byte(tt.stored))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeStoredPlan(%s) error = %v, wantErr %v", tt.stored, err, tt.wantErr)
+			}
+			if len(plan) != tt.wantSemesters {
+				t.Errorf("decodeStoredPlan(%s) = %d semesters, want %d", tt.stored, len(plan), tt.wantSemesters)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Package semester parses, orders and steps through ITU semester labels such as
+// "2021-2022 Güz Dönemi", "2022-2023 Yaz Okulu", "2021-2022 Fall Semester" or "3. Yarıyıl".
+package semester
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Terms within an academic year, in chronological order
+const (
+	TermUnknown = iota
+	TermFall    // Güz
+	TermSpring  // Bahar
+	TermSummer  // Yaz Dönemi / Yaz Okulu
+)
+
+// termKeywords maps the term names printed on transcripts to terms. Turkish
+// transcripts use Güz/Bahar/Yaz; English ones use Fall/Spring/Summer.
+var termKeywords = map[string]int{
+	"Güz":    TermFall,
+	"Bahar":  TermSpring,
+	"Yaz":    TermSummer,
+	"Fall":   TermFall,
+	"Autumn": TermFall,
+	"Spring": TermSpring,
+	"Summer": TermSummer,
+}
+
+// TermKeywordPattern returns a regexp alternation matching every known term keyword
+func TermKeywordPattern() string {
+	keywords := make([]string, 0, len(termKeywords))
+	for keyword := range termKeywords {
+		keywords = append(keywords, regexp.QuoteMeta(keyword))
+	}
+	sort.Strings(keywords)
+	return strings.Join(keywords, "|")
+}
+
+// labelPattern captures the academic year and term of a semester label
+var labelPattern = regexp.MustCompile(`(20\d{2})-(20\d{2})\s+(` + TermKeywordPattern() + `)`)
+
+// numberedPattern captures the number of a numbered semester label ("3. Yarıyıl")
+var numberedPattern = regexp.MustCompile(`(\d{1,2})\.\s*Yarıyıl`)
+
+// Key identifies a semester's position in the academic calendar.
+// Numbered semesters have no year; they use StartYear 0 and their number as Term.
+type Key struct {
+	StartYear int
+	Term      int
+}
+
+// Before reports whether k comes chronologically before other
+func (k Key) Before(other Key) bool {
+	if k.StartYear != other.StartYear {
+		return k.StartYear < other.StartYear
+	}
+	return k.Term < other.Term
+}
+
+// Numbered reports whether k is a numbered semester rather than a year and term
+func (k Key) Numbered() bool {
+	return k.StartYear == 0
+}
+
+// Next returns the following regular semester. Summer terms are optional, so
+// the semester after spring (or summer) is the next year's fall.
+func (k Key) Next() Key {
+	if k.Numbered() {
+		return Key{Term: k.Term + 1}
+	}
+	if k.Term == TermFall {
+		return Key{StartYear: k.StartYear, Term: TermSpring}
+	}
+	return Key{StartYear: k.StartYear + 1, Term: TermFall}
+}
+
+// Label formats k the way semesters are labelled on transcripts
+func (k Key) Label() string {
+	if k.Numbered() {
+		return fmt.Sprintf("%d. Yarıyıl", k.Term)
+	}
+
+	year := fmt.Sprintf("%d-%d", k.StartYear, k.StartYear+1)
+	switch k.Term {
+	case TermFall:
+		return year + " Güz Dönemi"
+	case TermSpring:
+		return year + " Bahar Dönemi"
+	case TermSummer:
+		return year + " Yaz Okulu"
+	}
+	return year
+}
+
+// Parse extracts the academic year and term from a semester label such as
+// "2021-2022 Bahar Dönemi", "2022-2023 Yaz Okulu", "2021-2022 Spring Term" or "3. Yarıyıl"
+func Parse(label string) (Key, bool) {
+	match := labelPattern.FindStringSubmatch(label)
+	if match == nil {
+		return parseNumbered(label)
+	}
+
+	startYear, err := strconv.Atoi(match[1])
+	if err != nil {
+		return Key{}, false
+	}
+
+	return Key{StartYear: startYear, Term: termKeywords[match[3]]}, true
+}
+
+// parseNumbered parses labels such as "1. Yarıyıl" that number semesters
+// from the start of the program instead of naming a year and term
+func parseNumbered(label string) (Key, bool) {
+	match := numberedPattern.FindStringSubmatch(label)
+	if match == nil {
+		return Key{}, false
+	}
+
+	number, err := strconv.Atoi(match[1])
+	if err != nil {
+		return Key{}, false
+	}
+	return Key{Term: number}, true
+}
+
+// Compare orders two semester labels chronologically, returning -1, 0 or 1.
+// Labels that can't be parsed sort after all recognized semesters, by name.
+func Compare(a, b string) int {
+	keyA, okA := Parse(a)
+	keyB, okB := Parse(b)
+
+	switch {
+	case okA && okB:
+		if keyA.Before(keyB) {
+			return -1
+		}
+		if keyB.Before(keyA) {
+			return 1
+		}
+	case okA:
+		return -1
+	case okB:
+		return 1
+	}
+
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Sort sorts semester labels chronologically in place
+func Sort(labels []string) {
+	sort.SliceStable(labels, func(i, j int) bool {
+		return Compare(labels[i], labels[j]) < 0
+	})
+}
@@ -0,0 +1,82 @@
+package semester
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		label  string
+		want   Key
+		wantOK bool
+	}{
+		{"2021-2022 Güz Dönemi", Key{StartYear: 2021, Term: TermFall}, true},
+		{"2022-2023 Yaz Okulu", Key{StartYear: 2022, Term: TermSummer}, true},
+		{"2021-2022 Fall Semester", Key{StartYear: 2021, Term: TermFall}, true},
+		{"2021-2022 Autumn Term", Key{StartYear: 2021, Term: TermFall}, true},
+		{"2021-2022 Spring Semester", Key{StartYear: 2021, Term: TermSpring}, true},
+		{"2022-2023 Summer School", Key{StartYear: 2022, Term: TermSummer}, true},
+		{"1. Yarıyıl", Key{Term: 1}, true},
+		{"10.Yarıyıl", Key{Term: 10}, true},
+		{"Yarıyıl", Key{}, false},
+		{"Transfer Credits", Key{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			got, ok := Parse(tt.label)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Parse(%q) = %+v, %v; want %+v, %v", tt.label, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []string
+		want   []string
+	}{
+		{
+			"numbered semesters by number",
+			[]string{"10. Yarıyıl", "2. Yarıyıl", "1. Yarıyıl"},
+			[]string{"1. Yarıyıl", "2. Yarıyıl", "10. Yarıyıl"},
+		},
+		{
+			"unrecognized labels last",
+			[]string{"Transfer Credits", "2. Yarıyıl", "1. Yarıyıl"},
+			[]string{"1. Yarıyıl", "2. Yarıyıl", "Transfer Credits"},
+		},
+		{
+			"English terms ordered like Turkish ones",
+			[]string{"2022-2023 Fall Semester", "2021-2022 Summer School", "2021-2022 Spring Semester", "2021-2022 Fall Semester"},
+			[]string{"2021-2022 Fall Semester", "2021-2022 Spring Semester", "2021-2022 Summer School", "2022-2023 Fall Semester"},
+		},
+		{
+			"mixed languages",
+			[]string{"2021-2022 Spring Semester", "2021-2022 Güz Dönemi"},
+			[]string{"2021-2022 Güz Dönemi", "2021-2022 Spring Semester"},
+		},
+		{
+			"dated semesters chronologically",
+			[]string{"2022-2023 Güz Dönemi", "2021-2022 Yaz Okulu", "2021-2022 Bahar Dönemi"},
+			[]string{"2021-2022 Bahar Dönemi", "2021-2022 Yaz Okulu", "2022-2023 Güz Dönemi"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := append([]string(nil), tt.labels...)
+			Sort(labels)
+			if !reflect.DeepEqual(labels, tt.want) {
+				t.Errorf("Sort(%q) = %q, want %q", tt.labels, labels, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyNextNumbered(t *testing.T) {
+	if got := (Key{Term: 3}).Next().Label(); got != "4. Yarıyıl" {
+		t.Errorf("Next of 3. Yarıyıl = %q, want 4. Yarıyıl", got)
+	}
+}
@@ -0,0 +1,75 @@
+package transcript
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// piiPatterns gathers the PIIPatterns of every institution. Stored courses don't
+// record the institution they were parsed for, so every known pattern is removed.
+var piiPatterns = collectPIIPatterns(institutionProfiles)
+
+// collectPIIPatterns returns the PIIPatterns of profiles in the order of their names
+func collectPIIPatterns(profiles map[string]*InstitutionProfile) []*regexp.Regexp {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var patterns []*regexp.Regexp
+	for _, name := range names {
+		patterns = append(patterns, profiles[name].PIIPatterns...)
+	}
+	return patterns
+}
+
+// stripPII returns a copy of courses with personal data removed from their names
+func stripPII(courses []Course) []Course {
+	stripped := make([]Course, len(courses))
+	for i, course := range courses {
+		course.Name = stripPIIText(course.Name)
+		stripped[i] = course
+	}
+	return stripped
+}
+
+// stripPIIText removes every piiPatterns match from text
+func stripPIIText(text string) string {
+	for _, pattern := range piiPatterns {
+		text = pattern.ReplaceAllString(text, "")
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// AnonymizedTranscriptResponse is a transcript safe to share: courses and GPA
+// figures without the user ID, notes or anything else that identifies the student
+type AnonymizedTranscriptResponse struct {
+	Program string    `json:"program,omitempty"`
+	Courses []Course  `json:"courses"`
+	GPA     GPAReport `json:"gpa"`
+}
+
+//encore:api public method=GET path=/transcript/:userID/anonymized
+func GetAnonymizedTranscript(ctx context.Context, userID string) (*AnonymizedTranscriptResponse, error) {
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Courses stored before PII stripping existed may still carry traces
+	courses := stripPII(transcript.Courses)
+	for i := range courses {
+		courses[i].Note = ""
+		courses[i].LessonID = ""
+		courses[i].AddedAt = nil
+	}
+
+	return &AnonymizedTranscriptResponse{
+		Program: transcript.Program,
+		Courses: courses,
+		GPA:     BuildGPAReport(courses),
+	}, nil
+}
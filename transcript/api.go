@@ -2,8 +2,14 @@ package transcript
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"encore.dev/beta/errs"
+	"encore.dev/rlog"
 	"fmt"
+	"strings"
 )
 
 //encore:api public method=POST path=/transcript
@@ -22,6 +28,22 @@ func StoreTranscript(ctx context.Context, req *StoreTranscriptRequest) (*StoreTr
 		}
 	}
 
+	if err := validateCourseCount(req.Courses); err != nil {
+		return nil, err
+	}
+
+	requestHash := idempotencyRequestHash(req)
+	if req.IdempotencyKey != "" {
+		var previous StoreTranscriptResponse
+		found, err := GetIdempotentResponse(ctx, storeTranscriptEndpoint, req.UserID, req.IdempotencyKey, requestHash, &previous)
+		if err != nil {
+			return nil, idempotencyError(err)
+		}
+		if found {
+			return &previous, nil
+		}
+	}
+
 	err := InsertTranscript(ctx, req.UserID, req.Courses)
 	if err != nil {
 		return nil, &errs.Error{
@@ -30,10 +52,13 @@ func StoreTranscript(ctx context.Context, req *StoreTranscriptRequest) (*StoreTr
 		}
 	}
 
-	return &StoreTranscriptResponse{
+	resp := &StoreTranscriptResponse{
 		Message: "Transcript stored successfully",
 		UserID:  req.UserID,
-	}, nil
+	}
+	rememberIdempotentResponse(ctx, storeTranscriptEndpoint, req.UserID, req.IdempotencyKey, requestHash, resp)
+
+	return resp, nil
 }
 
 //encore:api public method=GET path=/transcript/:userID
@@ -65,6 +90,73 @@ func GetTranscript(ctx context.Context, userID string) (*GetTranscriptResponse,
 	}, nil
 }
 
+//encore:api public method=GET path=/transcript/:userID/courses
+func ListTranscriptCourses(ctx context.Context, userID string, req *ListCoursesRequest) (*ListCoursesResponse, error) {
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	courses := transcript.Courses
+	if req.Grade != "" {
+		var grades []string
+		for _, grade := range strings.Split(req.Grade, ",") {
+			grade = normalizeGrade(grade)
+			if !isKnownGrade(grade) {
+				return nil, &errs.Error{
+					Code: errs.InvalidArgument,
+					Message: fmt.Sprintf("unknown grade: %q", grade),
+				}
+			}
+			grades = append(grades, grade)
+		}
+		courses = GetCoursesByGrade(courses, grades...)
+	}
+
+	if req.Tag != "" {
+		tags, err := GetCourseTags(ctx, userID)
+		if err != nil {
+			return nil, &errs.Error{
+				Code: errs.Internal,
+				Message: "failed to retrieve course tags",
+			}
+		}
+		courses = filterByTag(courses, tags, req.Tag)
+	}
+
+	if courses == nil {
+		courses = []Course{}
+	}
+
+	return &ListCoursesResponse{
+		Courses: courses,
+		Count:   len(courses),
+	}, nil
+}
+
+//encore:api public method=GET path=/transcript/:userID/search
+func SearchTranscriptCourses(ctx context.Context, userID string, req *SearchCoursesRequest) (*ListCoursesResponse, error) {
+	query := strings.TrimSpace(req.Q)
+	if query == "" {
+		return nil, &errs.Error{
+			Code: errs.InvalidArgument,
+			Message: "q is required",
+		}
+	}
+
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	courses := SearchCoursesByName(transcript.Courses, query)
+
+	return &ListCoursesResponse{
+		Courses: courses,
+		Count:   len(courses),
+	}, nil
+}
+
 //encore:api public method=PUT path=/transcript/:userID
 func UpdateTranscript(ctx context.Context, userID string, req *UpdateTranscriptRequest) (*UpdateTranscriptResponse, error) {
 	if userID == "" {
@@ -81,17 +173,49 @@ func UpdateTranscript(ctx context.Context, userID string, req *UpdateTranscriptR
 		}
 	}
 
-	err := UpdateTranscriptByUserID(ctx, userID, req.Courses)
+	if err := validateCourseCount(req.Courses); err != nil {
+		return nil, err
+	}
+
+	version, err := UpdateTranscriptByUserID(ctx, userID, req.Courses, req.Version)
 	if err != nil {
+		return nil, updateTranscriptError(err)
+	}
+
+	// Hand-edited courses must survive later reparses of the stored PDF
+	if err := SetTranscriptVerified(ctx, userID, true); err != nil {
 		return nil, &errs.Error{
 			Code: errs.Internal,
-			Message: "failed to update transcript",
+			Message: "failed to mark transcript as verified",
 		}
 	}
 
 	return &UpdateTranscriptResponse{
 		Message: "Transcript updated successfully",
 		UserID:  userID,
+		Version: version,
+	}, nil
+}
+
+// SetVerified marks a transcript as checked by hand, or clears the mark.
+// Verified transcripts are skipped when stored PDFs are reparsed.
+//
+//encore:api public method=PUT path=/transcript/:userID/verified
+func SetVerified(ctx context.Context, userID string, req *SetVerifiedRequest) (*SetVerifiedResponse, error) {
+	if _, err := loadTranscript(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := SetTranscriptVerified(ctx, userID, req.Verified); err != nil {
+		return nil, &errs.Error{
+			Code: errs.Internal,
+			Message: "failed to update verification",
+		}
+	}
+
+	return &SetVerifiedResponse{
+		UserID:   userID,
+		Verified: req.Verified,
 	}, nil
 }
 
@@ -118,6 +242,71 @@ func DeleteTranscript(ctx context.Context, userID string) (*DeleteTranscriptResp
 	}, nil
 }
 
+//encore:api public method=DELETE path=/transcript/:userID/semester
+func DeleteTranscriptSemester(ctx context.Context, userID string, req *DeleteSemesterRequest) (*DeleteSemesterResponse, error) {
+	if req.Name == "" {
+		return nil, &errs.Error{
+			Code: errs.InvalidArgument,
+			Message: "semester name is required",
+		}
+	}
+
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := []Course{}
+	for _, course := range transcript.Courses {
+		if course.Semester != req.Name {
+			remaining = append(remaining, course)
+		}
+	}
+
+	removed := len(transcript.Courses) - len(remaining)
+	if removed == 0 {
+		return nil, &errs.Error{
+			Code: errs.NotFound,
+			Message: "semester not found in transcript",
+		}
+	}
+
+	_, err = UpdateTranscriptByUserID(ctx, userID, remaining, transcript.Version)
+	if err != nil {
+		return nil, updateTranscriptError(err)
+	}
+
+	return &DeleteSemesterResponse{
+		Semester: req.Name,
+		Removed:  removed,
+	}, nil
+}
+
+// GetTranscriptSemester lists the courses of one semester. A semester with no
+// courses yields an empty list, so a 404 always means the transcript is missing.
+//
+//encore:api public method=GET path=/transcript/:userID/semester
+func GetTranscriptSemester(ctx context.Context, userID string, req *SemesterCoursesRequest) (*ListCoursesResponse, error) {
+	if req.Semester == "" {
+		return nil, &errs.Error{
+			Code: errs.InvalidArgument,
+			Message: "semester is required",
+		}
+	}
+
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	courses := GetCoursesBySemester(transcript.Courses, req.Semester)
+
+	return &ListCoursesResponse{
+		Courses: courses,
+		Count:   len(courses),
+	}, nil
+}
+
 //encore:api public method=GET path=/transcripts
 func ListAllTranscripts(ctx context.Context) (*ListTranscriptsResponse, error) {
 	transcripts, err := GetAllTranscripts(ctx)
@@ -138,13 +327,31 @@ func ListAllTranscripts(ctx context.Context) (*ListTranscriptsResponse, error) {
 type ParseAndStoreTranscriptRequest struct {
 	UserID   string `json:"userId"`
 	PDFBase64 string `json:"pdf_base64"`
+	// Optional key making retries of the same request return the original result
+	IdempotencyKey string `header:"Idempotency-Key"`
+	// Optional number of courses the student expects, see ParseTranscriptRequest
+	ExpectedCourseCount int `json:"expectedCourseCount,omitempty"`
+	// Debug includes the parser log in the response, see ParseTranscriptRequest
+	Debug bool `query:"debug"`
+	// Institution selects the transcript layout, see ParseTranscriptRequest
+	Institution string `query:"institution"`
+	// Optional student number to verify against the transcript, see ParseTranscriptRequest
+	VerifyStudentNumber string `json:"verifyStudentNumber,omitempty"`
+	// StoreStudentNumber keeps the transcript's student number with the stored
+	// transcript. It is off by default because the number is personal data.
+	StoreStudentNumber bool `json:"storeStudentNumber,omitempty"`
 }
 
 // ParseAndStoreTranscriptResponse represents the response
 type ParseAndStoreTranscriptResponse struct {
 	Transcript *Transcript `json:"transcript,omitempty"`
-	Error      string      `json:"error,omitempty"`
-	Debug      string      `json:"debug,omitempty"`
+	// StudentNumberMatch is set when a student number to verify was given
+	StudentNumberMatch *bool `json:"studentNumberMatch,omitempty"`
+	// ReviewWarning recommends manual review when the parse result looks incomplete
+	ReviewWarning string `json:"reviewWarning,omitempty"`
+	Error         string `json:"error,omitempty"`
+	Debug         string `json:"debug,omitempty"`
+	APIVersion    string `header:"X-API-Version"`
 }
 
 //encore:api public method=POST path=/parse-and-store-transcript
@@ -161,12 +368,45 @@ func ParseAndStoreTranscript(ctx context.Context, req *ParseAndStoreTranscriptRe
 		}, nil
 	}
 
+	requestHash := idempotencyRequestHash(req)
+	if req.IdempotencyKey != "" {
+		var previous ParseAndStoreTranscriptResponse
+		found, err := GetIdempotentResponse(ctx, parseAndStoreEndpoint, req.UserID, req.IdempotencyKey, requestHash, &previous)
+		if errors.Is(err, errIdempotencyKeyReused) {
+			return nil, idempotencyError(err)
+		}
+		if err != nil {
+			return &ParseAndStoreTranscriptResponse{
+				Error: fmt.Sprintf("Failed to check idempotency key: %v", err),
+			}, nil
+		}
+		if found {
+			return &previous, nil
+		}
+	}
+
+	// Decode the PDF once; the bytes are both parsed and stored
+	pdfBytes, failure, err := decodeUploadedPDF(req.PDFBase64)
+	if err != nil {
+		return &ParseAndStoreTranscriptResponse{
+			Error: fmt.Sprintf("Failed to parse transcript: %v", err),
+		}, nil
+	}
+	if failure != nil {
+		return &ParseAndStoreTranscriptResponse{
+			Error: failure.Error,
+		}, nil
+	}
+
 	// First, parse the transcript using the existing parsing logic
 	parseReq := &ParseTranscriptRequest{
-		PDFBase64: req.PDFBase64,
+		ExpectedCourseCount: req.ExpectedCourseCount,
+		Debug:               req.Debug,
+		Institution:         req.Institution,
+		VerifyStudentNumber: req.VerifyStudentNumber,
 	}
 
-	parseResp, err := ParseTranscript(ctx, parseReq)
+	parseResp, err := parseTranscriptPDF(ctx, parseReq, pdfBytes)
 	if err != nil {
 		return &ParseAndStoreTranscriptResponse{
 			Error: fmt.Sprintf("Failed to parse transcript: %v", err),
@@ -181,16 +421,10 @@ func ParseAndStoreTranscript(ctx context.Context, req *ParseAndStoreTranscriptRe
 	}
 
 	// Convert TranscriptCourse to Course for database storage
-	var courses []Course
-	for _, tc := range parseResp.Courses {
-		courses = append(courses, Course{
-			Semester: tc.Semester,
-			Code:     tc.Code,
-			Name:     tc.Name,
-			Credits:  tc.Credits,
-			Grade:    tc.Grade,
-			LessonID: tc.LessonID,
-		})
+	courses := toCourses(parseResp.Courses)
+
+	if err := validateCourseCount(courses); err != nil {
+		return nil, err
 	}
 
 	// Store the parsed transcript in the database
@@ -202,6 +436,55 @@ func ParseAndStoreTranscript(ctx context.Context, req *ParseAndStoreTranscriptRe
 		}, nil
 	}
 
+	// Keep the PDF so the transcript can be reparsed after parser fixes
+	err = SetTranscriptPDF(ctx, req.UserID, pdfBytes)
+	if err != nil {
+		return &ParseAndStoreTranscriptResponse{
+			Error: fmt.Sprintf("Failed to store PDF: %v", err),
+			Debug: parseResp.Debug,
+		}, nil
+	}
+
+	if parseResp.Program != "" {
+		err = SetTranscriptProgram(ctx, req.UserID, parseResp.Program)
+		if err != nil {
+			return &ParseAndStoreTranscriptResponse{
+				Error: fmt.Sprintf("Failed to store program: %v", err),
+				Debug: parseResp.Debug,
+			}, nil
+		}
+	}
+
+	if req.Institution != "" {
+		err = SetTranscriptInstitution(ctx, req.UserID, req.Institution)
+		if err != nil {
+			return &ParseAndStoreTranscriptResponse{
+				Error: fmt.Sprintf("Failed to store institution: %v", err),
+				Debug: parseResp.Debug,
+			}, nil
+		}
+	}
+
+	if req.StoreStudentNumber && parseResp.StudentNumber != "" {
+		err = SetTranscriptStudentNumber(ctx, req.UserID, parseResp.StudentNumber)
+		if err != nil {
+			return &ParseAndStoreTranscriptResponse{
+				Error: fmt.Sprintf("Failed to store student number: %v", err),
+				Debug: parseResp.Debug,
+			}, nil
+		}
+	}
+
+	if parseResp.Diagnostics != nil {
+		err = SetTranscriptParseLog(ctx, req.UserID, parseResp.Diagnostics)
+		if err != nil {
+			return &ParseAndStoreTranscriptResponse{
+				Error: fmt.Sprintf("Failed to store parse log: %v", err),
+				Debug: parseResp.Debug,
+			}, nil
+		}
+	}
+
 	// Retrieve the stored transcript to return
 	storedTranscript, err := GetTranscriptByUserID(ctx, req.UserID)
 	if err != nil {
@@ -211,16 +494,252 @@ func ParseAndStoreTranscript(ctx context.Context, req *ParseAndStoreTranscriptRe
 		}, nil
 	}
 
-	return &ParseAndStoreTranscriptResponse{
-		Transcript: storedTranscript,
-		Debug:      parseResp.Debug,
+	resp := &ParseAndStoreTranscriptResponse{
+		Transcript:         storedTranscript,
+		StudentNumberMatch: parseResp.StudentNumberMatch,
+		ReviewWarning:      parseResp.ReviewWarning,
+		Debug:              parseResp.Debug,
+	}
+	rememberIdempotentResponse(ctx, parseAndStoreEndpoint, req.UserID, req.IdempotencyKey, requestHash, resp)
+
+	return resp, nil
+}
+
+//encore:api public method=POST path=/transcript/:userID/append-parse
+func AppendParseTranscript(ctx context.Context, userID string, req *AppendParseRequest) (*AppendParseResponse, error) {
+	if userID == "" {
+		return nil, &errs.Error{
+			Code: errs.InvalidArgument,
+			Message: "user_id is required",
+		}
+	}
+
+	if req.PDFBase64 == "" {
+		return nil, &errs.Error{
+			Code: errs.InvalidArgument,
+			Message: "pdf_base64 is required",
+		}
+	}
+
+	existing, err := GetTranscriptByUserID(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Code: errs.Internal,
+			Message: "failed to retrieve transcript",
+		}
+	}
+
+	// Without an explicit institution the new pages are parsed like the stored ones
+	institution := req.Institution
+	if institution == "" && existing != nil {
+		institution = existing.Institution
+	}
+
+	parseResp, err := ParseTranscript(ctx, &ParseTranscriptRequest{
+		PDFBase64:   req.PDFBase64,
+		Institution: institution,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if parseResp.Error != "" {
+		return &AppendParseResponse{
+			Error: parseResp.Error,
+			Debug: parseResp.Debug,
+		}, nil
+	}
+
+	var merged []Course
+	if existing != nil {
+		merged = append(merged, existing.Courses...)
+	}
+
+	// Only semesters missing from the stored transcript are added, so
+	// earlier (possibly edited) semesters are left untouched
+	present := make(map[string]bool)
+	for _, semester := range semestersOf(merged) {
+		present[semester] = true
+	}
+
+	parsed := toCourses(parseResp.Courses)
+	addedSemesters := []string{}
+	for _, semester := range semestersOf(parsed) {
+		if present[semester] {
+			continue
+		}
+		merged = append(merged, GetCoursesBySemester(parsed, semester)...)
+		addedSemesters = append(addedSemesters, semester)
+	}
+
+	if len(addedSemesters) > 0 {
+		if err := validateCourseCount(merged); err != nil {
+			return nil, err
+		}
+
+		if existing == nil {
+			err = InsertTranscript(ctx, userID, merged)
+		} else {
+			// Guard the merge against a write since existing was read
+			_, err = UpdateTranscriptByUserID(ctx, userID, merged, existing.Version)
+		}
+		if errors.Is(err, errVersionConflict) {
+			return nil, updateTranscriptError(err)
+		}
+		if err != nil {
+			return nil, &errs.Error{
+				Code: errs.Internal,
+				Message: "failed to store transcript",
+			}
+		}
+	}
+
+	if institution != "" && (existing == nil || existing.Institution != institution) {
+		if err := SetTranscriptInstitution(ctx, userID, institution); err != nil {
+			return nil, &errs.Error{
+				Code: errs.Internal,
+				Message: "failed to store institution",
+			}
+		}
+	}
+
+	stored, err := GetTranscriptByUserID(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Code: errs.Internal,
+			Message: "failed to retrieve transcript",
+		}
+	}
+
+	return &AppendParseResponse{
+		Transcript:     stored,
+		AddedSemesters: addedSemesters,
+		Debug:          parseResp.Debug,
+	}, nil
+}
+
+//encore:api public method=GET path=/transcript/:userID/latest-semester
+func GetLatestSemester(ctx context.Context, userID string) (*SemesterSummaryResponse, error) {
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	semester, ok := latestSemester(transcript.Courses)
+	if !ok {
+		return nil, &errs.Error{
+			Code: errs.NotFound,
+			Message: "transcript has no semesters",
+		}
+	}
+
+	courses := GetCoursesBySemester(transcript.Courses, semester)
+	return &SemesterSummaryResponse{
+		Semester:   semester,
+		Courses:    courses,
+		GPASummary: CalculateGPASummary(courses),
 	}, nil
 }
 
+// loadTranscript retrieves a user's transcript, mapping missing users and
+// database failures to API errors
+func loadTranscript(ctx context.Context, userID string) (*Transcript, error) {
+	if userID == "" {
+		return nil, &errs.Error{
+			Code: errs.InvalidArgument,
+			Message: "user_id is required",
+		}
+	}
+
+	transcript, err := GetTranscriptByUserID(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Code: errs.Internal,
+			Message: "failed to retrieve transcript",
+		}
+	}
+
+	if transcript == nil {
+		return nil, &errs.Error{
+			Code: errs.NotFound,
+			Message: "transcript not found",
+		}
+	}
+
+	return transcript, nil
+}
+
+// Endpoint names idempotency keys are scoped to
+const (
+	storeTranscriptEndpoint = "store-transcript"
+	parseAndStoreEndpoint   = "parse-and-store-transcript"
+)
+
+// rememberIdempotentResponse stores a successful response under the user's
+// idempotency key, if the request had one. Failing to store it only costs a
+// repeated request later, so errors are logged rather than returned.
+func rememberIdempotentResponse(ctx context.Context, endpoint, userID, key, requestHash string, resp any) {
+	if key == "" {
+		return
+	}
+	if err := SaveIdempotentResponse(ctx, endpoint, userID, key, requestHash, resp, idempotencyKeyTTL); err != nil {
+		rlog.Error("failed to store idempotency key", "endpoint", endpoint, "err", err)
+	}
+}
+
+// idempotencyRequestHash hashes a request body so a reused idempotency key can
+// be told apart from a retry of the same request
+func idempotencyRequestHash(req any) string {
+	body, _ := json.Marshal(req) // Request types are plain data and always marshal
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// idempotencyError converts an idempotency key lookup error to an API error
+func idempotencyError(err error) error {
+	if errors.Is(err, errIdempotencyKeyReused) {
+		return &errs.Error{
+			Code: errs.Aborted,
+			Message: "idempotency key was already used with a different request",
+		}
+	}
+	return &errs.Error{
+		Code: errs.Internal,
+		Message: "failed to check idempotency key",
+	}
+}
+
+// updateTranscriptError converts an UpdateTranscriptByUserID error to an API
+// error, reporting a concurrent write as a conflict the caller can retry
+func updateTranscriptError(err error) error {
+	if errors.Is(err, errVersionConflict) {
+		return &errs.Error{
+			Code: errs.Aborted,
+			Message: "transcript was modified by another request; reload it and retry",
+		}
+	}
+	return &errs.Error{
+		Code: errs.Internal,
+		Message: "failed to update transcript",
+	}
+}
+
+// validateCourseCount rejects transcripts exceeding maxCoursesPerTranscript
+func validateCourseCount(courses []Course) error {
+	if len(courses) > maxCoursesPerTranscript {
+		return &errs.Error{
+			Code: errs.InvalidArgument,
+			Message: fmt.Sprintf("too many courses: %d exceeds the limit of %d", len(courses), maxCoursesPerTranscript),
+		}
+	}
+	return nil
+}
+
 // Request and Response types
 type StoreTranscriptRequest struct {
 	UserID  string   `json:"userId"`
 	Courses []Course `json:"courses"`
+	// Optional key making retries of the same request return the original result
+	IdempotencyKey string `header:"Idempotency-Key"`
 }
 
 type StoreTranscriptResponse struct {
@@ -230,15 +749,28 @@ type StoreTranscriptResponse struct {
 
 type GetTranscriptResponse struct {
 	Transcript *Transcript `json:"transcript"`
+	APIVersion string      `header:"X-API-Version"`
 }
 
 type UpdateTranscriptRequest struct {
 	Courses []Course `json:"courses"`
+	// Version is the transcript version the client read; 0 skips the check
+	Version int64 `json:"version,omitempty"`
 }
 
 type UpdateTranscriptResponse struct {
 	Message string `json:"message"`
 	UserID  string `json:"userId"`
+	Version int64  `json:"version"`
+}
+
+type SetVerifiedRequest struct {
+	Verified bool `json:"verified"`
+}
+
+type SetVerifiedResponse struct {
+	UserID   string `json:"userId"`
+	Verified bool   `json:"verified"`
 }
 
 type DeleteTranscriptResponse struct {
@@ -249,4 +781,60 @@ type DeleteTranscriptResponse struct {
 type ListTranscriptsResponse struct {
 	Transcripts []Transcript `json:"transcripts"`
 	Count       int          `json:"count"`
-} 
\ No newline at end of file
+	APIVersion  string       `header:"X-API-Version"`
+} 
+
+type ListCoursesRequest struct {
+	// Comma-separated list of grades to filter by, e.g. "AA,BA"
+	Grade string `query:"grade"`
+	// Only return courses carrying this student-defined tag
+	Tag string `query:"tag"`
+}
+
+type SearchCoursesRequest struct {
+	// Substring to look for in course names, matched Turkish-case-insensitively
+	Q string `query:"q"`
+}
+
+type ListCoursesResponse struct {
+	Courses    []Course `json:"courses"`
+	Count      int      `json:"count"`
+	APIVersion string   `header:"X-API-Version"`
+}
+
+type AppendParseRequest struct {
+	PDFBase64 string `json:"pdf_base64"`
+	// Institution selects the transcript layout; defaults to the institution
+	// the stored transcript was parsed with
+	Institution string `query:"institution"`
+}
+
+type AppendParseResponse struct {
+	Transcript     *Transcript `json:"transcript,omitempty"`
+	AddedSemesters []string    `json:"addedSemesters"`
+	Error          string      `json:"error,omitempty"`
+	Debug          string      `json:"debug,omitempty"`
+	APIVersion     string      `header:"X-API-Version"`
+}
+
+type SemesterSummaryResponse struct {
+	Semester string   `json:"semester"`
+	Courses  []Course `json:"courses"`
+	GPASummary
+}
+
+type DeleteSemesterRequest struct {
+	// Semester label to remove, e.g. "2021-2022 Bahar Dönemi"
+	Name string `query:"name"`
+}
+
+type SemesterCoursesRequest struct {
+	// Semester label exactly as parsed, e.g. "2021-2022 Bahar Dönemi". Clients
+	// percent-encode it; query values arrive here already URL-decoded.
+	Semester string `query:"semester"`
+}
+
+type DeleteSemesterResponse struct {
+	Semester string `json:"semester"`
+	Removed  int    `json:"removed"`
+}
@@ -0,0 +1,44 @@
+package transcript
+
+import "testing"
+
+func TestIdempotencyRequestHash(t *testing.T) {
+	base := &StoreTranscriptRequest{
+		UserID:         "user-1",
+		Courses:        []Course{{Semester: "2021-2022 Güz Dönemi", Code: "MAT 103E", Credits: "4", Grade: "AA"}},
+		IdempotencyKey: "key-1",
+	}
+	retry := &StoreTranscriptRequest{
+		UserID:         "user-1",
+		Courses:        []Course{{Semester: "2021-2022 Güz Dönemi", Code: "MAT 103E", Credits: "4", Grade: "AA"}},
+		IdempotencyKey: "key-1",
+	}
+	changed := &StoreTranscriptRequest{
+		UserID:         "user-1",
+		Courses:        []Course{{Semester: "2021-2022 Güz Dönemi", Code: "MAT 103E", Credits: "4", Grade: "BA"}},
+		IdempotencyKey: "key-1",
+	}
+	otherUser := &StoreTranscriptRequest{
+		UserID:         "user-2",
+		Courses:        base.Courses,
+		IdempotencyKey: "key-1",
+	}
+
+	tests := []struct {
+		name string
+		req  *StoreTranscriptRequest
+		same bool
+	}{
+		{"retry of the same request", retry, true},
+		{"different grade", changed, false},
+		{"different user", otherUser, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := idempotencyRequestHash(tt.req) == idempotencyRequestHash(base)
+			if got != tt.same {
+				t.Errorf("hashes equal = %v, want %v", got, tt.same)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package transcript
+
+import (
+	"context"
+	"time"
+
+	"encore.dev"
+	"encore.dev/beta/errs"
+)
+
+// CourseAudit is one entry of the grade change log
+type CourseAudit struct {
+	UserID    string    `json:"userId"`
+	Semester  string    `json:"semester"`
+	Code      string    `json:"code"`
+	OldGrade  string    `json:"oldGrade"`
+	NewGrade  string    `json:"newGrade"`
+	ChangedAt time.Time `json:"changedAt"`
+	ChangedBy string    `json:"changedBy"`
+}
+
+// changedByHeader lets callers name who made a change; without it the audit
+// log records the endpoint that made it
+const changedByHeader = "X-Changed-By"
+
+// gradeChanges lists the courses whose grade differs between the stored and
+// new versions of a transcript. Removed courses are recorded with an empty new grade.
+func gradeChanges(userID string, before, after []Course) []CourseAudit {
+	newGrades := make(map[string]string, len(after))
+	for _, course := range after {
+		newGrades[courseKey(course)] = course.Grade
+	}
+
+	by := changedBy()
+	var audits []CourseAudit
+	for _, course := range before {
+		newGrade, ok := newGrades[courseKey(course)]
+		if ok && newGrade == course.Grade {
+			continue
+		}
+		audits = append(audits, CourseAudit{
+			UserID:    userID,
+			Semester:  course.Semester,
+			Code:      course.Code,
+			OldGrade:  course.Grade,
+			NewGrade:  newGrade,
+			ChangedBy: by,
+		})
+	}
+	return audits
+}
+
+// changedBy identifies who is making the current change
+func changedBy() string {
+	req := encore.CurrentRequest()
+	if req == nil {
+		return "system"
+	}
+	if by := req.Headers.Get(changedByHeader); by != "" {
+		return by
+	}
+	if req.Endpoint != "" {
+		return req.Service + "." + req.Endpoint
+	}
+	return "system"
+}
+
+// CourseAuditResponse lists a transcript's grade changes, newest first
+type CourseAuditResponse struct {
+	Entries []CourseAudit `json:"entries"`
+}
+
+//encore:api public method=GET path=/transcript/:userID/audit
+func GetCourseAudit(ctx context.Context, userID string) (*CourseAuditResponse, error) {
+	if userID == "" {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "user_id is required",
+		}
+	}
+
+	entries, err := GetCourseAudits(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve audit log",
+		}
+	}
+
+	return &CourseAuditResponse{Entries: entries}, nil
+}
@@ -0,0 +1,115 @@
+package transcript
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+// BatchParseItem is a single PDF submitted to the batch parse endpoint
+type BatchParseItem struct {
+	// Caller-chosen identifier echoed back in the result
+	ID string `json:"id"`
+	// PDF file content as base64 encoded string
+	PDFBase64 string `json:"pdf_base64"`
+}
+
+// BatchParseRequest represents the request body
+type BatchParseRequest struct {
+	Items []BatchParseItem `json:"items"`
+	// MaxConcurrency limits how many PDFs are parsed at once; defaults to the
+	// number of CPUs and is capped at maxBatchConcurrency
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+}
+
+// BatchParseResult is the outcome of parsing a single batch item
+type BatchParseResult struct {
+	ID         string             `json:"id"`
+	Courses    []TranscriptCourse `json:"courses"`
+	Program    string             `json:"program,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	DurationMs int64              `json:"durationMs"`
+}
+
+// BatchParseResponse holds the per-item results in request order
+type BatchParseResponse struct {
+	Results    []BatchParseResult `json:"results"`
+	DurationMs int64              `json:"durationMs"`
+}
+
+// BatchParseTranscripts parses several transcript PDFs, running at most
+// MaxConcurrency parses at a time.
+//
+//encore:api public method=POST path=/parse-transcripts-batch
+func BatchParseTranscripts(ctx context.Context, req *BatchParseRequest) (*BatchParseResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "items cannot be empty",
+		}
+	}
+	if req.MaxConcurrency < 0 {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "maxConcurrency cannot be negative",
+		}
+	}
+
+	start := time.Now()
+	results := make([]BatchParseResult, len(req.Items))
+	sem := make(chan struct{}, batchConcurrency(req.MaxConcurrency))
+	var wg sync.WaitGroup
+	for i, item := range req.Items {
+		wg.Add(1)
+		go func(i int, item BatchParseItem) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = parseBatchItem(ctx, item)
+		}(i, item)
+	}
+	wg.Wait()
+
+	return &BatchParseResponse{
+		Results:    results,
+		DurationMs: time.Since(start).Milliseconds(),
+	}, nil
+}
+
+// batchConcurrency resolves the requested concurrency to a value between 1 and maxBatchConcurrency
+func batchConcurrency(requested int) int {
+	concurrency := requested
+	if concurrency == 0 {
+		concurrency = runtime.NumCPU()
+	}
+	if concurrency > maxBatchConcurrency {
+		concurrency = maxBatchConcurrency
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return concurrency
+}
+
+// parseBatchItem parses a single batch item, timing the parse
+func parseBatchItem(ctx context.Context, item BatchParseItem) BatchParseResult {
+	start := time.Now()
+	result := BatchParseResult{ID: item.ID, Courses: []TranscriptCourse{}}
+
+	resp, err := ParseTranscript(ctx, &ParseTranscriptRequest{PDFBase64: item.PDFBase64})
+	switch {
+	case err != nil:
+		result.Error = err.Error()
+	case resp.Error != "":
+		result.Error = resp.Error
+	default:
+		result.Courses = resp.Courses
+		result.Program = resp.Program
+	}
+
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result
+}
@@ -0,0 +1,152 @@
+package transcript
+
+import (
+	"container/list"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"encore.dev/metrics"
+)
+
+// Transcript cache hit and miss counters
+var (
+	transcriptCacheHits   = metrics.NewCounter[uint64]("transcript_cache_hits", metrics.CounterConfig{})
+	transcriptCacheMisses = metrics.NewCounter[uint64]("transcript_cache_misses", metrics.CounterConfig{})
+)
+
+// cacheGenerationStripes is how many write generation counters users are
+// spread over; users sharing a stripe only skip each other's racing puts
+const cacheGenerationStripes = 256
+
+// transcriptCache is a size-bounded LRU cache of transcripts by user ID whose
+// entries expire after a TTL. It is safe for concurrent use.
+//
+// Readers take the user's write generation before loading a transcript and
+// pass it to put. Every invalidation bumps the generation, so a transcript read
+// before a write committed is never cached after the write invalidated it.
+type transcriptCache struct {
+	mu          sync.Mutex
+	entries     map[string]*list.Element
+	order       *list.List // Front is the most recently used
+	generations [cacheGenerationStripes]uint64
+}
+
+// cachedTranscript is one entry of a transcriptCache
+type cachedTranscript struct {
+	userID     string
+	transcript Transcript
+	expiresAt  time.Time
+}
+
+// transcripts caches GetTranscriptByUserID results. Every function writing the
+// cached columns invalidates the user's entry.
+var transcripts = &transcriptCache{
+	entries: make(map[string]*list.Element),
+	order:   list.New(),
+}
+
+// get returns a copy of the cached transcript of a user, if present and fresh
+func (c *transcriptCache) get(userID string, now time.Time) (*Transcript, bool) {
+	if !transcriptCacheEnabled {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[userID]
+	if !ok {
+		transcriptCacheMisses.Increment()
+		return nil, false
+	}
+	entry := element.Value.(*cachedTranscript)
+	if now.After(entry.expiresAt) {
+		c.remove(element)
+		transcriptCacheMisses.Increment()
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	transcriptCacheHits.Increment()
+	return copyTranscript(&entry.transcript), true
+}
+
+// generation returns the write generation of a user, to be taken before
+// loading the transcript that is later passed to put
+func (c *transcriptCache) generation(userID string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generations[generationStripe(userID)]
+}
+
+// put caches a copy of a user's transcript loaded at the given write
+// generation, evicting the least recently used entries beyond
+// transcriptCacheSize. The transcript is dropped if the user's transcript was
+// invalidated since, as it may predate that write.
+func (c *transcriptCache) put(userID string, transcript *Transcript, generation uint64, now time.Time) {
+	if !transcriptCacheEnabled || transcriptCacheSize <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[generationStripe(userID)] != generation {
+		return
+	}
+
+	entry := &cachedTranscript{
+		userID:     userID,
+		transcript: *copyTranscript(transcript),
+		expiresAt:  now.Add(transcriptCacheTTL),
+	}
+	if element, ok := c.entries[userID]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[userID] = c.order.PushFront(entry)
+
+	for c.order.Len() > transcriptCacheSize {
+		c.remove(c.order.Back())
+	}
+}
+
+// invalidate drops the cached transcript of a user after a write and bumps the
+// user's write generation so reads racing the write aren't cached
+func (c *transcriptCache) invalidate(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[generationStripe(userID)]++
+
+	if element, ok := c.entries[userID]; ok {
+		c.remove(element)
+	}
+}
+
+// generationStripe returns the write generation counter a user maps to
+func generationStripe(userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(userID))
+	return int(h.Sum32() % cacheGenerationStripes)
+}
+
+// remove drops an entry; the caller must hold c.mu
+func (c *transcriptCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cachedTranscript).userID)
+}
+
+// copyTranscript returns a copy of transcript that shares no course slice with
+// it, so callers can't modify a cached transcript
+func copyTranscript(transcript *Transcript) *Transcript {
+	copied := *transcript
+	if transcript.Courses != nil {
+		copied.Courses = make([]Course, len(transcript.Courses))
+		copy(copied.Courses, transcript.Courses)
+	}
+	return &copied
+}
@@ -0,0 +1,40 @@
+package transcript
+
+import (
+	"container/list"
+	"testing"
+	"time"
+)
+
+func newTestCache() *transcriptCache {
+	return &transcriptCache{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func TestTranscriptCacheSkipsPutRacingWrite(t *testing.T) {
+	now := time.Now()
+	stale := &Transcript{UserID: "user-1", Version: 1}
+
+	tests := []struct {
+		name       string
+		write      func(c *transcriptCache)
+		wantCached bool
+	}{
+		{"no write during the read", func(c *transcriptCache) {}, true},
+		{"write to the user during the read", func(c *transcriptCache) { c.invalidate("user-1") }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCache()
+			generation := c.generation("user-1")
+			tt.write(c)
+			c.put("user-1", stale, generation, now)
+
+			if _, cached := c.entries["user-1"]; cached != tt.wantCached {
+				t.Errorf("cached = %v, want %v", cached, tt.wantCached)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package transcript
+
+import (
+	"context"
+	"strconv"
+
+	"encore.app/coursecode"
+	"encore.dev/beta/errs"
+)
+
+// UpsertCatalogRequest represents the request body
+type UpsertCatalogRequest struct {
+	Courses []CatalogCourse `json:"courses"`
+}
+
+// UpsertCatalogResponse reports how many catalog entries were written
+type UpsertCatalogResponse struct {
+	Upserted int `json:"upserted"`
+}
+
+//encore:api public method=POST path=/catalog
+func UpsertCatalog(ctx context.Context, req *UpsertCatalogRequest) (*UpsertCatalogResponse, error) {
+	for _, course := range req.Courses {
+		if course.Code == "" {
+			return nil, &errs.Error{
+				Code:    errs.InvalidArgument,
+				Message: "catalog course code is required",
+			}
+		}
+		if course.Credits < 0 {
+			return nil, &errs.Error{
+				Code:    errs.InvalidArgument,
+				Message: "catalog credits cannot be negative: " + course.Code,
+			}
+		}
+	}
+
+	if err := UpsertCatalogCourses(ctx, req.Courses); err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to store catalog",
+		}
+	}
+
+	return &UpsertCatalogResponse{Upserted: len(req.Courses)}, nil
+}
+
+// BackfillCreditsResponse reports the courses whose credits were corrected
+type BackfillCreditsResponse struct {
+	Corrected int      `json:"corrected"`
+	Courses   []Course `json:"courses"`
+}
+
+// BackfillCredits replaces zero credits that the parser produced with the
+// catalog value for the course code, flagging each corrected course.
+//
+//encore:api public method=POST path=/transcript/:userID/backfill-credits
+func BackfillCredits(ctx context.Context, userID string) (*BackfillCreditsResponse, error) {
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []string
+	for _, course := range transcript.Courses {
+		if hasZeroCredits(course) {
+			codes = append(codes, course.Code)
+		}
+	}
+
+	resp := &BackfillCreditsResponse{Courses: []Course{}}
+	if len(codes) == 0 {
+		return resp, nil
+	}
+
+	catalog, err := GetCatalogCourses(ctx, codes)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve catalog",
+		}
+	}
+
+	courses := make([]Course, len(transcript.Courses))
+	copy(courses, transcript.Courses)
+	for i, course := range courses {
+		entry, ok := catalog[coursecode.Normalize(course.Code)]
+		if !hasZeroCredits(course) || !ok || entry.Credits <= 0 {
+			continue
+		}
+		courses[i].Credits = strconv.FormatFloat(entry.Credits, 'f', -1, 64)
+		courses[i].CreditsFromCatalog = true
+		resp.Courses = append(resp.Courses, courses[i])
+	}
+
+	resp.Corrected = len(resp.Courses)
+	if resp.Corrected == 0 {
+		return resp, nil
+	}
+
+	if _, err := UpdateTranscriptByUserID(ctx, userID, courses, transcript.Version); err != nil {
+		return nil, updateTranscriptError(err)
+	}
+
+	return resp, nil
+}
+
+// hasZeroCredits reports whether a course's credits parsed as exactly zero
+func hasZeroCredits(course Course) bool {
+	credits, err := parseFloat(course.Credits)
+	return err == nil && credits == 0
+}
@@ -0,0 +1,102 @@
+package transcript
+
+import (
+	"context"
+	"sort"
+
+	"encore.app/coursecode"
+	"encore.app/grades"
+)
+
+// TakenCourse is a distinct course a student has taken, with the best grade
+// achieved across its attempts
+type TakenCourse struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	BestGrade string `json:"bestGrade"`
+	// Semester is when the best grade was achieved
+	Semester string `json:"semester"`
+	Attempts int    `json:"attempts"`
+}
+
+// TakenCoursesResponse lists the distinct courses of a transcript by code
+type TakenCoursesResponse struct {
+	Courses []TakenCourse `json:"courses"`
+}
+
+// GetTakenCourses lists the distinct normalized course codes a student has
+// taken, resolving retakes to the best grade achieved
+//
+//encore:api public method=GET path=/transcript/:userID/codes
+func GetTakenCourses(ctx context.Context, userID string) (*TakenCoursesResponse, error) {
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &TakenCoursesResponse{Courses: []TakenCourse{}}
+	for _, course := range bestAttempts(transcript.Courses) {
+		resp.Courses = append(resp.Courses, TakenCourse{
+			Code:      course.Code,
+			Name:      course.Name,
+			BestGrade: course.Grade,
+			Semester:  course.Semester,
+			Attempts:  countAttempts(transcript.Courses, course.Code),
+		})
+	}
+	return resp, nil
+}
+
+// bestAttempts resolves retakes: it returns one course per normalized code,
+// the attempt with the best grade, ordered by code. Ties go to the later attempt.
+func bestAttempts(courses []Course) []Course {
+	best := make(map[string]Course)
+	for _, course := range courses {
+		course.Code = coursecode.Normalize(course.Code)
+		current, ok := best[course.Code]
+		if !ok || !gradeBetter(current.Grade, course.Grade) {
+			best[course.Code] = course
+		}
+	}
+
+	attempts := make([]Course, 0, len(best))
+	for _, course := range best {
+		attempts = append(attempts, course)
+	}
+	sort.Slice(attempts, func(i, j int) bool { return attempts[i].Code < attempts[j].Code })
+	return attempts
+}
+
+// countAttempts counts the courses with the given normalized code
+func countAttempts(courses []Course, code string) int {
+	count := 0
+	for _, course := range courses {
+		if coursecode.Normalize(course.Code) == code {
+			count++
+		}
+	}
+	return count
+}
+
+// gradeBetter reports whether grade a is strictly better than grade b. Grades
+// earning credits beat failing grades, which beat grades without an outcome
+// (in progress or withdrawn); within a tier the default grade scale decides.
+func gradeBetter(a, b string) bool {
+	if tierA, tierB := gradeTier(a), gradeTier(b); tierA != tierB {
+		return tierA > tierB
+	}
+	scale := gradeScales[defaultProfile().GradeScale]
+	return scale[a] > scale[b]
+}
+
+// gradeTier ranks a grade's outcome: 2 if it earns credits, 1 if it fails and
+// 0 if the course has no outcome yet
+func gradeTier(grade string) int {
+	switch {
+	case grades.IsInProgress(grade) || grades.IsWithdrawn(grade):
+		return 0
+	case nonEarningGrades[grade]:
+		return 1
+	}
+	return 2
+}
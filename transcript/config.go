@@ -0,0 +1,140 @@
+package transcript
+
+import (
+	"regexp"
+	"time"
+)
+
+// Service-wide tunables for the transcript service.
+
+// maxCoursesPerTranscript caps how many courses a single transcript may hold.
+// Legitimate transcripts rarely exceed ~80 courses, so this only guards the
+// JSONB column against abusive payloads.
+var maxCoursesPerTranscript = 200
+
+// pdfExtractAttempts is how many times PDF text extraction is attempted
+// before a transient failure is reported to the caller.
+var pdfExtractAttempts = 3
+
+// pdfRetryBackoff is the base delay between extraction attempts; it grows
+// linearly with each retry.
+var pdfRetryBackoff = 50 * time.Millisecond
+
+// pdfParseTimeout bounds how long PDF text extraction may run for a single request.
+var pdfParseTimeout = 30 * time.Second
+
+// gpaTrendWindow is how many recent semesters the GPA trend direction considers.
+var gpaTrendWindow = 3
+
+// gpaTrendThreshold is the minimum GPA change across the trend window that
+// counts as improving or declining rather than stable.
+var gpaTrendThreshold = 0.1
+
+// defaultInstitution names the entry of institutionProfiles used when a request
+// doesn't select one, and for every GPA computed over stored transcripts.
+var defaultInstitution = "itu"
+
+// compilePatterns compiles a list of regular expressions
+func compilePatterns(exprs ...string) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, 0, len(exprs))
+	for _, expr := range exprs {
+		patterns = append(patterns, regexp.MustCompile(expr))
+	}
+	return patterns
+}
+
+// minProgramCohortSize is the privacy threshold for program statistics: cohorts
+// with fewer students are refused so individuals can't be singled out.
+var minProgramCohortSize = 5
+
+// extractTextEndpointEnabled turns on the raw text extraction endpoint used by
+// support to debug parse issues. It is off by default because the extracted
+// text contains the student's personal data.
+var extractTextEndpointEnabled = false
+
+// maxBatchConcurrency caps how many PDFs the batch parse endpoint parses at
+// once, whatever the caller asks for, so large batches can't starve other requests.
+var maxBatchConcurrency = 16
+
+// maxFailedPageRatio is the largest fraction of PDF pages that may fail to
+// extract before the whole extraction is reported as failed rather than
+// returning partial text.
+var maxFailedPageRatio = 0.5
+
+// idempotencyKeyTTL is how long a store request's Idempotency-Key is remembered;
+// retries within this window get the original response back.
+var idempotencyKeyTTL = 24 * time.Hour
+
+// normalizeCourseCodesOnStore canonicalizes course codes ("BLG102E" becomes
+// "BLG 102E") whenever a transcript is written. Lookups normalize regardless.
+var normalizeCourseCodesOnStore = true
+
+// minTranscriptTextLength is the fewest characters of extracted text worth
+// parsing. Anything shorter (such as a lone cover page) can't hold a transcript.
+var minTranscriptTextLength = 200
+
+// courseCountTolerance is the fraction by which the parsed course count may
+// differ from the student's expected count before the result is flagged for review.
+var courseCountTolerance = 0.2
+
+// parseExchangeSections enables the exchange (Erasmus) section parser, which
+// picks up foreign-format courses that the ITU course patterns drop.
+var parseExchangeSections = true
+
+// transcriptHistoryEnabled keeps a snapshot of every stored or updated transcript
+// in transcript_version. By default only the latest transcript is kept.
+var transcriptHistoryEnabled = false
+
+// englishTermSuffixes is the regexp alternation of words that follow the term
+// name in English semester headings, e.g. "2021-2022 Fall Semester".
+var englishTermSuffixes = `Semester|Term|School`
+
+// gradeMaps translate ITU letter grades to partner institutions' scales for the
+// JSON export. Grades missing from a map (pass/fail and in-progress markers) are
+// exported without a mapped grade.
+var gradeMaps = map[string]map[string]string{
+	// ects approximates the ECTS A–F scale
+	"ects": {
+		"AA": "A", "BA+": "A", "BA": "B", "BB+": "B", "BB": "C",
+		"CB+": "C", "CB": "D", "CC+": "D", "CC": "E", "DC+": "E",
+		"DC": "E", "DD+": "E", "DD": "E", "FF": "F", "VF": "F",
+	},
+	// us follows the US letter scale with plus/minus grades
+	"us": {
+		"AA": "A", "BA+": "A-", "BA": "A-", "BB+": "B+", "BB": "B",
+		"CB+": "B-", "CB": "B-", "CC+": "C+", "CC": "C", "DC+": "C-",
+		"DC": "C-", "DD+": "D+", "DD": "D", "FF": "F", "VF": "F",
+	},
+}
+
+// maxPDFSizeBytes caps the decoded size of an uploaded PDF. Transcripts are a
+// few hundred kilobytes, so this only rejects mistaken or abusive uploads.
+var maxPDFSizeBytes = 20 << 20
+
+// maxPDFFiles caps how many PDFs one transcript can be uploaded as
+var maxPDFFiles = 5
+
+// maxTotalPDFSizeBytes caps the combined decoded size of the PDFs of a
+// multi-file upload
+var maxTotalPDFSizeBytes = 40 << 20
+
+// creditTotalTolerance is how far the summed course credits (or ECTS) may be
+// from the transcript's printed TUK (or TAKTS) before a diagnostic is raised.
+var creditTotalTolerance = 0.5
+
+// transcriptCacheEnabled puts an in-memory cache in front of transcript reads.
+// Each instance keeps its own cache, so a write made through another instance
+// can be served stale for up to transcriptCacheTTL.
+var transcriptCacheEnabled = true
+
+// transcriptCacheTTL is how long a cached transcript is served before it is reloaded.
+var transcriptCacheTTL = time.Minute
+
+// transcriptCacheSize caps how many transcripts are cached; the least recently
+// used one is evicted beyond it.
+var transcriptCacheSize = 1000
+
+// defaultUnknownGradeExport is how exports treat courses whose grade isn't a
+// known grade when the request doesn't say: one of unknownGradeInclude,
+// unknownGradeExclude or unknownGradeFlag.
+var defaultUnknownGradeExport = unknownGradeInclude
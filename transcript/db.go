@@ -1,6 +1,8 @@
 package transcript
 
 import (
+	"time"
+
 	"encore.dev/storage/sqldb"
 )
 
@@ -17,11 +19,42 @@ type Course struct {
 	Credits  string `json:"credits"`
 	Grade    string `json:"grade"`
 	LessonID string `json:"lesson_id,omitempty"`
+	// ECTS is the course's AKTS value, when known
+	ECTS string `json:"ects,omitempty"`
+	// Points is the grade points column (Puan) as printed on the transcript
+	Points string `json:"points,omitempty"`
+	// CreditsFromECTS marks credits taken from the AKTS column because UK was blank
+	CreditsFromECTS bool `json:"credits_from_ects,omitempty"`
+	// CreditsFromCatalog marks credits taken from the course catalog because the parsed value was 0
+	CreditsFromCatalog bool `json:"credits_from_catalog,omitempty"`
+	// InProgress marks a course that has no grade yet
+	InProgress bool `json:"in_progress,omitempty"`
+	// Exchange marks a course taken abroad (e.g. Erasmus); it is kept out of the GPA
+	Exchange bool `json:"exchange,omitempty"`
+	// Language is the instruction language of an exchange course, when listed
+	Language string `json:"language,omitempty"`
+	// Note is a free-form annotation by the student or an advisor
+	Note string `json:"note,omitempty"`
+	// AddedAt records when the course first entered the stored transcript
+	AddedAt *time.Time `json:"added_at,omitempty"`
 }
 
 // Transcript represents a user's transcript with courses
 type Transcript struct {
-	ID      int64    `json:"id"`
-	UserID  string   `json:"userId"`
-	Courses []Course `json:"courses"`
-} 
\ No newline at end of file
+	ID      int64  `json:"id"`
+	UserID  string `json:"userId"`
+	Program string `json:"program,omitempty"`
+	// Institution the transcript was parsed with; empty means defaultInstitution
+	Institution string   `json:"institution,omitempty"`
+	Courses     []Course `json:"courses"`
+	// Version increases on every course write; pass it back on update to detect conflicts
+	Version int64 `json:"version"`
+}
+
+// CatalogCourse is the reference entry for a course code
+type CatalogCourse struct {
+	Code     string  `json:"code"`
+	Name     string  `json:"name"`
+	Credits  float64 `json:"credits"`
+	LessonID string  `json:"lesson_id,omitempty"`
+}
@@ -0,0 +1,251 @@
+package transcript
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"encore.dev/beta/errs"
+)
+
+// Kinds of parse warnings reported in ParseDiagnostics
+const (
+	WarningDuplicateRemoved    = "duplicate_removed"
+	WarningInTermRepeat        = "in_term_repeat"
+	WarningTextRepaired        = "text_repaired"
+	WarningPageExtractFailed   = "page_extract_failed"
+	WarningNoCourses           = "no_courses"
+	WarningCourseCountMismatch = "course_count_mismatch"
+	WarningCreditTotalMismatch = "credit_total_mismatch"
+	WarningFileFailed          = "file_failed"
+)
+
+// ParseWarning is a structured note about something the parser worked around
+type ParseWarning struct {
+	Kind     string `json:"kind"`
+	Message  string `json:"message"`
+	Semester string `json:"semester,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
+
+// NoCoursesReport explains why text was extracted but no courses were parsed
+type NoCoursesReport struct {
+	SemestersFound   bool   `json:"semestersFound"`
+	CourseCodesFound bool   `json:"courseCodesFound"`
+	Reason           string `json:"reason"`
+}
+
+// FileReport describes one PDF of a transcript uploaded as several files
+type FileReport struct {
+	Index      int    `json:"index"`
+	Pages      int    `json:"pages"`
+	Characters int    `json:"characters"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ParseDiagnostics summarizes the decisions made while parsing a transcript
+type ParseDiagnostics struct {
+	Warnings  []ParseWarning   `json:"warnings,omitempty"`
+	NoCourses *NoCoursesReport `json:"noCourses,omitempty"`
+	// Files reports each uploaded PDF when a transcript was split across several
+	Files []FileReport `json:"files,omitempty"`
+}
+
+// String renders the diagnostics as plain text, one warning per line
+func (d *ParseDiagnostics) String() string {
+	var b strings.Builder
+	if d.NoCourses != nil {
+		fmt.Fprintf(&b, "No courses found: %s\n", d.NoCourses.Reason)
+	}
+	if len(d.Warnings) == 0 {
+		b.WriteString("No parse warnings\n")
+	}
+	for _, w := range d.Warnings {
+		fmt.Fprintf(&b, "[%s]", w.Kind)
+		if w.Semester != "" {
+			fmt.Fprintf(&b, " %s", w.Semester)
+		}
+		if w.Code != "" {
+			fmt.Fprintf(&b, " %s", w.Code)
+		}
+		fmt.Fprintf(&b, ": %s\n", w.Message)
+	}
+	return b.String()
+}
+
+// warn records a parse warning
+func (d *ParseDiagnostics) warn(kind, semester, code, message string) {
+	d.Warnings = append(d.Warnings, ParseWarning{
+		Kind:     kind,
+		Message:  message,
+		Semester: semester,
+		Code:     code,
+	})
+}
+
+// ParseWarningsResponse is the warnings-only view of a stored transcript's
+// parse log, for pipelines deciding which uploads need manual review
+type ParseWarningsResponse struct {
+	Warnings []ParseWarning `json:"warnings"`
+}
+
+// GetParseWarnings returns only the structured warnings recorded when a user's
+// stored transcript was parsed, without courses or debug output. A parse that
+// failed stored nothing; parse-transcript with warningsOnly reports its warnings.
+//
+//encore:api public method=GET path=/transcript/:userID/warnings
+func GetParseWarnings(ctx context.Context, userID string) (*ParseWarningsResponse, error) {
+	diagnostics, err := GetTranscriptParseLog(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve parse log",
+		}
+	}
+	if diagnostics == nil {
+		return nil, &errs.Error{
+			Code:    errs.NotFound,
+			Message: "no parse log found for user; parse the PDF with warningsOnly to see why it fails",
+		}
+	}
+
+	resp := &ParseWarningsResponse{Warnings: []ParseWarning{}}
+	resp.Warnings = append(resp.Warnings, diagnostics.Warnings...)
+	return resp, nil
+}
+
+// courseCodeSignal matches anything shaped like a course code ("MAT 103E")
+var courseCodeSignal = regexp.MustCompile(`[A-Z]{2,4}\s+\d{3}[A-Z]?`)
+
+// diagnoseNoCourses inspects text that yielded no courses and records the most
+// likely reason
+func (d *ParseDiagnostics) diagnoseNoCourses(text string, profile *InstitutionProfile) *NoCoursesReport {
+	report := &NoCoursesReport{
+		SemestersFound:   profile.SemesterPattern.MatchString(text),
+		CourseCodesFound: courseCodeSignal.MatchString(text),
+	}
+
+	switch {
+	case !report.SemestersFound && !report.CourseCodesFound:
+		report.Reason = "no semesters or course codes found; the PDF may not be a transcript or may be a scanned image"
+	case !report.SemestersFound:
+		report.Reason = "course codes found but no semester headings; the transcript layout may not be supported"
+	case !report.CourseCodesFound:
+		report.Reason = "semesters found but no course codes; the course table may not have been extracted"
+	default:
+		report.Reason = "semesters and course codes found but no recognizable course rows; the row format may not be supported"
+	}
+
+	d.NoCourses = report
+	d.warn(WarningNoCourses, "", "", report.Reason)
+	return report
+}
+
+// CourseTrace shows how the parser derived one course, for writing regression
+// tests from real PDFs
+type CourseTrace struct {
+	Semester string `json:"semester"`
+	Code     string `json:"code"`
+	// Branch is the parser branch that produced the course
+	Branch string `json:"branch"`
+	// RawText is the slice of transcript text the fields were extracted from
+	RawText string `json:"rawText"`
+	// Fields holds the non-empty extracted fields by their JSON name
+	Fields map[string]string `json:"fields"`
+}
+
+// traceCourses builds the per-course parse traces reported in debug mode
+func traceCourses(courses []TranscriptCourse) []CourseTrace {
+	traces := make([]CourseTrace, 0, len(courses))
+	for _, course := range courses {
+		fields := make(map[string]string)
+		for name, value := range map[string]string{
+			"name":      course.Name,
+			"credits":   course.Credits,
+			"grade":     course.Grade,
+			"ects":      course.ECTS,
+			"points":    course.Points,
+			"lesson_id": course.LessonID,
+			"language":  course.Language,
+		} {
+			if value != "" {
+				fields[name] = value
+			}
+		}
+
+		traces = append(traces, CourseTrace{
+			Semester: course.Semester,
+			Code:     course.Code,
+			Branch:   course.ParseSource,
+			RawText:  course.RawText,
+			Fields:   fields,
+		})
+	}
+	return traces
+}
+
+// OfficialTotals are the cumulative totals printed in the transcript's summary lines
+type OfficialTotals struct {
+	// Credits is the TUK (total national credits) value
+	Credits float64 `json:"credits"`
+	// ECTS is the TAKTS (total ECTS) value; 0 when not printed
+	ECTS float64 `json:"ects,omitempty"`
+}
+
+// Patterns for the cumulative totals in semester summary lines
+var (
+	tukPattern   = regexp.MustCompile(`TUK\s*:\s*(\d+(?:[.,]\d+)?)`)
+	taktsPattern = regexp.MustCompile(`TAKTS\s*:\s*(\d+(?:[.,]\d+)?)`)
+)
+
+// parseOfficialTotals reads the TUK and TAKTS totals from text. Summary lines
+// are cumulative, so the last occurrence holds the transcript's totals. It
+// returns nil when the text has no TUK value.
+func parseOfficialTotals(text string) *OfficialTotals {
+	credits, ok := lastNumber(tukPattern, text)
+	if !ok {
+		return nil
+	}
+	ects, _ := lastNumber(taktsPattern, text)
+	return &OfficialTotals{Credits: credits, ECTS: ects}
+}
+
+// lastNumber returns the number captured by the last match of pattern in text
+func lastNumber(pattern *regexp.Regexp, text string) (float64, bool) {
+	matches := pattern.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	value, err := parseFloat(strings.Replace(matches[len(matches)-1][1], ",", ".", 1))
+	return value, err == nil
+}
+
+// checkOfficialTotals compares the official totals against the earned credits
+// and ECTS summed from the parsed courses and warns when they disagree
+func (d *ParseDiagnostics) checkOfficialTotals(totals *OfficialTotals, courses []TranscriptCourse) {
+	earned := toCourses(courses)
+	credits := CalculateEarnedCredits(earned)
+	if math.Abs(credits-totals.Credits) > creditTotalTolerance {
+		d.warn(WarningCreditTotalMismatch, "", "", fmt.Sprintf(
+			"parsed courses sum to %.1f credits but the transcript states TUK %.1f", credits, totals.Credits))
+	}
+
+	if totals.ECTS == 0 {
+		return
+	}
+	ects := 0.0
+	for _, course := range earned {
+		if !isEarnedCourse(course) {
+			continue
+		}
+		if value, err := parseFloat(course.ECTS); err == nil {
+			ects += value
+		}
+	}
+	if math.Abs(ects-totals.ECTS) > creditTotalTolerance {
+		d.warn(WarningCreditTotalMismatch, "", "", fmt.Sprintf(
+			"parsed courses sum to %.1f ECTS but the transcript states TAKTS %.1f", ects, totals.ECTS))
+	}
+}
@@ -0,0 +1,62 @@
+package transcript
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOfficialTotals(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want *OfficialTotals
+	}{
+		{"no footer", "2022-2023 Güz Dönemi\nBLG 101E Introduction to Computing İng. 3 0 3 5 BB 3.00\n", nil},
+		{"TUK and TAKTS", "DNO: 3.00 TUK: 3 TAKTS: 5 GNO: 3.00\n", &OfficialTotals{Credits: 3, ECTS: 5}},
+		{"TUK only", "TUK: 12.5\n", &OfficialTotals{Credits: 12.5}},
+		{"decimal comma", "TUK : 7,5 TAKTS : 10,5\n", &OfficialTotals{Credits: 7.5, ECTS: 10.5}},
+		{"cumulative lines use the last", "TUK: 3 TAKTS: 5\nTUK: 7 TAKTS: 11\n", &OfficialTotals{Credits: 7, ECTS: 11}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseOfficialTotals(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseOfficialTotals() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckOfficialTotals(t *testing.T) {
+	courses := []TranscriptCourse{
+		{Semester: "2022-2023 Güz Dönemi", Code: "BLG 101E", Credits: "3", ECTS: "5", Grade: "BB"},
+		{Semester: "2022-2023 Güz Dönemi", Code: "MAT 103E", Credits: "4", ECTS: "6", Grade: "FF"},
+		{Semester: "2022-2023 Bahar Dönemi", Code: "FIZ 102E", Credits: "4", ECTS: "6", Grade: "W"},
+		{Semester: "2022-2023 Bahar Dönemi", Code: "KIM 101E", Credits: "3", ECTS: "5", Grade: "Devam"},
+		{Semester: "2022-2023 Bahar Dönemi", Code: "INF 201", Credits: "3", ECTS: "4", Grade: "F", Exchange: true},
+	}
+
+	tests := []struct {
+		name         string
+		totals       OfficialTotals
+		wantWarnings int
+	}{
+		{"totals agree", OfficialTotals{Credits: 3, ECTS: 5}, 0},
+		{"TAKTS not printed", OfficialTotals{Credits: 3}, 0},
+		{"credits differ", OfficialTotals{Credits: 6, ECTS: 5}, 1},
+		{"credits and ECTS differ", OfficialTotals{Credits: 7, ECTS: 11}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := &ParseDiagnostics{}
+			diagnostics.checkOfficialTotals(&tt.totals, courses)
+			if len(diagnostics.Warnings) != tt.wantWarnings {
+				t.Fatalf("got %d warnings, want %d: %+v", len(diagnostics.Warnings), tt.wantWarnings, diagnostics.Warnings)
+			}
+			for _, w := range diagnostics.Warnings {
+				if w.Kind != WarningCreditTotalMismatch {
+					t.Errorf("warning kind = %q, want %q", w.Kind, WarningCreditTotalMismatch)
+				}
+			}
+		})
+	}
+}
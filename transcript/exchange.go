@@ -0,0 +1,66 @@
+package transcript
+
+import "strings"
+
+// parseExchangeCourses captures the foreign-format courses listed in exchange
+// (Erasmus) sections of text. Each block is attributed to the semester heading
+// preceding it and ends at the next semester heading.
+func parseExchangeCourses(text string, profile *InstitutionProfile) []TranscriptCourse {
+	text = normalizeWhitespace(text)
+
+	var courses []TranscriptCourse
+	semesters := profile.SemesterPattern.FindAllStringIndex(text, -1)
+	covered := 0
+	for _, heading := range profile.ExchangeSectionPattern.FindAllStringIndex(text, -1) {
+		if heading[0] < covered {
+			continue // Another keyword within a block already parsed, e.g. "Erasmus Değişim Programı"
+		}
+
+		semesterName := ""
+		end := len(text)
+		for _, match := range semesters {
+			if match[0] < heading[0] {
+				semesterName = text[match[0]:match[1]]
+			} else if match[0] >= heading[1] {
+				end = match[0]
+				break
+			}
+		}
+		covered = end
+
+		courses = append(courses, parseExchangeBlock(text[heading[1]:end], semesterName, profile)...)
+	}
+	return courses
+}
+
+// parseExchangeBlock extracts the exchange course rows of a single block
+func parseExchangeBlock(block, semesterName string, profile *InstitutionProfile) []TranscriptCourse {
+	var courses []TranscriptCourse
+	pattern := profile.ExchangeCoursePattern
+	for _, match := range pattern.FindAllStringSubmatch(block, -1) {
+		group := func(name string) string {
+			if i := pattern.SubexpIndex(name); i > 0 {
+				return strings.TrimSpace(match[i])
+			}
+			return ""
+		}
+
+		code := group("code")
+		if profile.CourseCodePattern.MatchString(code) {
+			continue // Already picked up by the main parser
+		}
+
+		courses = append(courses, TranscriptCourse{
+			Semester:    semesterName,
+			Code:        code,
+			Name:        group("name"),
+			Credits:     strings.Replace(group("credits"), ",", ".", 1),
+			Grade:       group("grade"),
+			Exchange:    true,
+			Language:    group("language"),
+			ParseSource: "exchange",
+			RawText:     match[0],
+		})
+	}
+	return courses
+}
@@ -0,0 +1,55 @@
+package transcript
+
+import "testing"
+
+// sampleExchangeTranscript is a transcript excerpt with an Erasmus block
+// between two ITU semesters
+const sampleExchangeTranscript = `2022-2023 Güz Dönemi
+BLG 335E Analysis of Algorithms I Tr 3 0 3 5 BB 3.00
+Erasmus Değişim Programı
+INF 1234 Software Engineering 6 B English
+IN2064 Machine Learning 8 5,0 German
+MAT 2 Stochastics 5 NP
+2022-2023 Bahar Dönemi
+BLG 336E Analysis of Algorithms II Tr 3 0 3 5 CB 2.50
+`
+
+func TestParseExchangeCourses(t *testing.T) {
+	parsed := parseExchangeCourses(sampleExchangeTranscript, defaultProfile())
+
+	want := []struct {
+		code     string
+		credits  string
+		grade    string
+		language string
+	}{
+		{"INF 1234", "6", "B", "English"},
+		{"IN2064", "8", "5,0", "German"},
+		{"MAT 2", "5", "NP", ""},
+	}
+	if len(parsed) != len(want) {
+		t.Fatalf("parsed %d exchange courses, want %d: %+v", len(parsed), len(want), parsed)
+	}
+	for i, w := range want {
+		course := parsed[i]
+		if course.Code != w.code || course.Credits != w.credits || course.Grade != w.grade || course.Language != w.language {
+			t.Errorf("course %d = %s %s %s %s, want %s %s %s %s", i,
+				course.Code, course.Credits, course.Grade, course.Language, w.code, w.credits, w.grade, w.language)
+		}
+		if !course.Exchange {
+			t.Errorf("course %d: Exchange not set", i)
+		}
+		if course.Semester != "2022-2023 Güz Dönemi" {
+			t.Errorf("course %d: semester = %q, want the heading before the block", i, course.Semester)
+		}
+	}
+
+	// Only the passed exchange course earns credits, and none affect the GPA
+	courses := toCourses(parsed)
+	if got := CalculateEarnedCredits(courses); got != 6 {
+		t.Errorf("earned credits = %v, want 6 from the passed course only", got)
+	}
+	if summary := CalculateGPASummary(courses); summary.TotalCredits != 0 {
+		t.Errorf("GPA credits = %v, want exchange courses kept out of the GPA", summary.TotalCredits)
+	}
+}
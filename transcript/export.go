@@ -0,0 +1,302 @@
+package transcript
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"encore.dev"
+	"encore.dev/beta/errs"
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// summaryFont is the embedded font family used in summary PDFs; the PDF core
+// fonts can't render Turkish characters such as ş, ğ and ı
+const summaryFont = "Go"
+
+// ExportTranscriptSummary renders a one-page style summary of a user's
+// transcript, with courses grouped by semester and GPA figures. It is not
+// the official transcript.
+//
+//encore:api public raw method=GET path=/transcript/:userID/summary.pdf
+func ExportTranscriptSummary(w http.ResponseWriter, req *http.Request) {
+	userID := encore.CurrentRequest().PathParams.Get("userID")
+
+	transcript, err := loadTranscript(req.Context(), userID)
+	if err != nil {
+		errs.HTTPError(w, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := renderSummaryPDF(transcript).Output(&buf); err != nil {
+		errs.HTTPError(w, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to render summary PDF",
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", "transcript-summary.pdf"))
+	w.Write(buf.Bytes())
+}
+
+// renderSummaryPDF lays out the transcript summary document
+func renderSummaryPDF(transcript *Transcript) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(summaryFont, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(summaryFont, "B", gobold.TTF)
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	pdf.SetFont(summaryFont, "B", 16)
+	pdf.CellFormat(0, 10, "Transcript Summary", "", 1, "L", false, 0, "")
+
+	pdf.SetFont(summaryFont, "", 10)
+	pdf.CellFormat(0, 6, "Student: "+transcript.UserID, "", 1, "L", false, 0, "")
+	if transcript.Program != "" {
+		pdf.CellFormat(0, 6, "Program: "+transcript.Program, "", 1, "L", false, 0, "")
+	}
+	summary := CalculateGPASummary(transcript.Courses)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Cumulative GPA: %.2f    GPA credits: %.1f    Earned credits: %.1f    Graded courses: %d",
+		summary.GPA, summary.TotalCredits, summary.EarnedCredits, summary.CourseCount), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	for _, semester := range semestersOf(transcript.Courses) {
+		courses := GetCoursesBySemester(transcript.Courses, semester)
+		semesterSummary := CalculateGPASummary(courses)
+
+		pdf.SetFont(summaryFont, "B", 11)
+		pdf.CellFormat(120, 7, semester, "B", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 7, fmt.Sprintf("GPA %.2f / %.1f cr", semesterSummary.GPA, semesterSummary.TotalCredits), "B", 1, "R", false, 0, "")
+
+		pdf.SetFont(summaryFont, "", 9)
+		for _, course := range courses {
+			pdf.CellFormat(25, 5, course.Code, "", 0, "L", false, 0, "")
+			pdf.CellFormat(125, 5, course.Name, "", 0, "L", false, 0, "")
+			pdf.CellFormat(15, 5, course.Credits, "", 0, "R", false, 0, "")
+			pdf.CellFormat(0, 5, course.Grade, "", 1, "R", false, 0, "")
+		}
+		pdf.Ln(3)
+	}
+
+	return pdf
+}
+
+// ExportParseLog downloads the diagnostics of the parse that produced a user's
+// stored transcript as a text file, for attaching to support tickets
+//
+//encore:api public raw method=GET path=/transcript/:userID/parse-log.txt
+func ExportParseLog(w http.ResponseWriter, req *http.Request) {
+	userID := encore.CurrentRequest().PathParams.Get("userID")
+
+	diagnostics, err := GetTranscriptParseLog(req.Context(), userID)
+	if err != nil {
+		errs.HTTPError(w, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve parse log",
+		})
+		return
+	}
+	if diagnostics == nil {
+		errs.HTTPError(w, &errs.Error{
+			Code:    errs.NotFound,
+			Message: "no parse log found for user",
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "parse-log.txt"))
+	fmt.Fprintf(w, "Parse log for user %s\n\n", userID)
+	w.Write([]byte(diagnostics.String()))
+}
+
+// Export options for courses whose grade isn't a known grade
+const (
+	unknownGradeInclude = "include" // export them like any other course
+	unknownGradeExclude = "exclude" // leave them out of the export
+	unknownGradeFlag    = "flag"    // export them marked as unknown
+)
+
+// unknownGradeOption validates an unknownGrades export option, defaulting to
+// defaultUnknownGradeExport
+func unknownGradeOption(value string) (string, error) {
+	switch value {
+	case "":
+		return defaultUnknownGradeExport, nil
+	case unknownGradeInclude, unknownGradeExclude, unknownGradeFlag:
+		return value, nil
+	}
+	return "", &errs.Error{
+		Code:    errs.InvalidArgument,
+		Message: "unknownGrades must be include, exclude or flag",
+	}
+}
+
+// ExportCSV exports a user's courses as CSV in the import column layout, so the
+// file can be re-imported. includePoints=true appends a points column and
+// unknownGrades=flag appends an unknown_grade column (see unknownGradeOption).
+//
+//encore:api public raw method=GET path=/transcript/:userID/export.csv
+func ExportCSV(w http.ResponseWriter, req *http.Request) {
+	userID := encore.CurrentRequest().PathParams.Get("userID")
+
+	includePoints, err := parseBoolQuery(req, "includePoints")
+	if err != nil {
+		errs.HTTPError(w, err)
+		return
+	}
+	unknownGrades, err := unknownGradeOption(req.URL.Query().Get("unknownGrades"))
+	if err != nil {
+		errs.HTTPError(w, err)
+		return
+	}
+
+	transcript, err := loadTranscript(req.Context(), userID)
+	if err != nil {
+		errs.HTTPError(w, err)
+		return
+	}
+
+	header := append([]string{}, csvHeader...)
+	if includePoints {
+		header = append(header, "points")
+	}
+	if unknownGrades == unknownGradeFlag {
+		header = append(header, "unknown_grade")
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(header)
+	for _, course := range transcript.Courses {
+		unknown := hasUnknownGrade(course)
+		if unknown && unknownGrades == unknownGradeExclude {
+			continue
+		}
+		record := []string{course.Semester, course.Code, course.Name, course.Credits, course.Grade}
+		if includePoints {
+			record = append(record, course.Points)
+		}
+		if unknownGrades == unknownGradeFlag {
+			record = append(record, strconv.FormatBool(unknown))
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		errs.HTTPError(w, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to write CSV",
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "transcript.csv"))
+	w.Write(buf.Bytes())
+}
+
+// parseBoolQuery reads an optional boolean query parameter, defaulting to false
+func parseBoolQuery(req *http.Request, name string) (bool, error) {
+	value := req.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: fmt.Sprintf("%s must be true or false", name),
+		}
+	}
+	return parsed, nil
+}
+
+// ExportJSONRequest represents the export options
+type ExportJSONRequest struct {
+	// IncludePoints keeps each course's grade points in the export
+	IncludePoints bool `query:"includePoints"`
+	// GradeMap names an entry of gradeMaps (e.g. "ects" or "us") to translate grades to
+	GradeMap string `query:"gradeMap"`
+	// UnknownGrades includes, excludes or flags courses with an unrecognized grade
+	UnknownGrades string `query:"unknownGrades"`
+}
+
+// ExportedCourse is a course in the JSON export. Grade is always the original
+// ITU grade; MappedGrade is its translation when a grade map was requested and
+// stays empty for grades the map has no entry for (pass/fail, in-progress or
+// exchange grades).
+type ExportedCourse struct {
+	Course
+	MappedGrade string `json:"mappedGrade,omitempty"`
+	// UnknownGrade marks a grade the GPA utilities don't recognize; only set
+	// when unknown grades are flagged
+	UnknownGrade bool `json:"unknownGrade,omitempty"`
+}
+
+// ExportJSONResponse represents the exported courses
+type ExportJSONResponse struct {
+	UserID   string           `json:"userId"`
+	Program  string           `json:"program,omitempty"`
+	GradeMap string           `json:"gradeMap,omitempty"`
+	Courses  []ExportedCourse `json:"courses"`
+}
+
+//encore:api public method=GET path=/transcript/:userID/export.json
+func ExportJSON(ctx context.Context, userID string, req *ExportJSONRequest) (*ExportJSONResponse, error) {
+	var gradeMap map[string]string
+	if req.GradeMap != "" {
+		var ok bool
+		if gradeMap, ok = gradeMaps[req.GradeMap]; !ok {
+			return nil, &errs.Error{
+				Code:    errs.InvalidArgument,
+				Message: fmt.Sprintf("unknown grade map %q", req.GradeMap),
+			}
+		}
+	}
+
+	unknownGrades, err := unknownGradeOption(req.UnknownGrades)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	courses := make([]ExportedCourse, 0, len(transcript.Courses))
+	for _, course := range transcript.Courses {
+		unknown := hasUnknownGrade(course)
+		if unknown && unknownGrades == unknownGradeExclude {
+			continue
+		}
+		if !req.IncludePoints {
+			course.Points = ""
+		}
+		exported := ExportedCourse{
+			Course:       course,
+			UnknownGrade: unknown && unknownGrades == unknownGradeFlag,
+		}
+		if !course.Exchange {
+			exported.MappedGrade = gradeMap[course.Grade]
+		}
+		courses = append(courses, exported)
+	}
+
+	return &ExportJSONResponse{
+		UserID:   transcript.UserID,
+		Program:  transcript.Program,
+		GradeMap: req.GradeMap,
+		Courses:  courses,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package transcript
+
+import (
+	"errors"
+	"testing"
+
+	"encore.dev/beta/errs"
+)
+
+func TestUnknownGradeOption(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", defaultUnknownGradeExport, false},
+		{"include", unknownGradeInclude, false},
+		{"exclude", unknownGradeExclude, false},
+		{"flag", unknownGradeFlag, false},
+		{"drop", "", true},
+		{"Flag", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := unknownGradeOption(tt.value)
+			if tt.wantErr {
+				var apiErr *errs.Error
+				if !errors.As(err, &apiErr) || apiErr.Code != errs.InvalidArgument {
+					t.Errorf("unknownGradeOption(%q) error = %v, want InvalidArgument", tt.value, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("unknownGradeOption(%q) = %q, %v; want %q", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
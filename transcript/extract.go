@@ -0,0 +1,61 @@
+package transcript
+
+import (
+	"context"
+
+	"encore.dev/beta/errs"
+)
+
+// ExtractTextRequest represents the request body
+type ExtractTextRequest struct {
+	// PDF file content as base64 encoded string
+	PDFBase64 string `json:"pdf_base64"`
+}
+
+// ExtractTextResponse holds the raw text extracted from a PDF
+type ExtractTextResponse struct {
+	Text        string `json:"text"`
+	PageCount   int    `json:"pageCount"`
+	FailedPages []int  `json:"failedPages,omitempty"`
+}
+
+// ExtractText returns the raw text extracted from a PDF without parsing it, so
+// support can see what the parser works with. It is disabled unless
+// extractTextEndpointEnabled is set since the text may contain personal data.
+//
+//encore:api public method=POST path=/extract-text
+func ExtractText(ctx context.Context, req *ExtractTextRequest) (*ExtractTextResponse, error) {
+	if !extractTextEndpointEnabled {
+		return nil, &errs.Error{
+			Code:    errs.PermissionDenied,
+			Message: "text extraction endpoint is disabled",
+		}
+	}
+
+	pdfBytes, err := decodePDFBase64(req.PDFBase64)
+	if err != nil {
+		return nil, pdfDecodeError(err)
+	}
+	if err := checkPDFContentType(pdfBytes); err != nil {
+		return nil, err
+	}
+
+	extractCtx, cancel := context.WithTimeout(ctx, pdfParseTimeout)
+	defer cancel()
+	extracted, err := extractTextWithRetry(extractCtx, pdfBytes)
+	if ctxErr := extractionContextError(err); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "failed to extract text from PDF",
+		}
+	}
+
+	return &ExtractTextResponse{
+		Text:        extracted.text,
+		PageCount:   extracted.pages,
+		FailedPages: extracted.failedPages,
+	}, nil
+}
@@ -0,0 +1,548 @@
+package transcript
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"encore.app/coursecode"
+	"encore.app/grades"
+	"encore.app/semester"
+	"encore.dev/beta/errs"
+)
+
+// Trend directions reported by the GPA trend endpoint
+const (
+	TrendImproving = "improving"
+	TrendDeclining = "declining"
+	TrendStable    = "stable"
+)
+
+// GPATrendPoint is one semester of the GPA trend series
+type GPATrendPoint struct {
+	Semester      string  `json:"semester"`
+	SemesterGPA   float64 `json:"semesterGpa"`
+	CumulativeGPA float64 `json:"cumulativeGpa"`
+}
+
+// GPATrendResponse represents the GPA series of a transcript
+type GPATrendResponse struct {
+	Points    []GPATrendPoint `json:"points"`
+	Direction string          `json:"direction"`
+}
+
+//encore:api public method=GET path=/transcript/:userID/gpa-trend
+func GetGPATrend(ctx context.Context, userID string) (*GPATrendResponse, error) {
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	points := []GPATrendPoint{}
+	var taken []Course
+	for _, semester := range semestersOf(transcript.Courses) {
+		courses := GetCoursesBySemester(transcript.Courses, semester)
+		taken = append(taken, courses...)
+
+		summary := CalculateGPASummary(courses)
+		if summary.TotalCredits == 0 {
+			continue // Nothing graded this semester, so there is no point to plot
+		}
+
+		points = append(points, GPATrendPoint{
+			Semester:      semester,
+			SemesterGPA:   summary.GPA,
+			CumulativeGPA: CalculateGPASummary(taken).GPA,
+		})
+	}
+
+	return &GPATrendResponse{
+		Points:    points,
+		Direction: trendDirection(points),
+	}, nil
+}
+
+// DetailedCourse is one row of the detailed transcript, with the running totals
+// up to and including the course
+type DetailedCourse struct {
+	Course
+	CumulativeCredits float64 `json:"cumulativeCredits"`
+	CumulativeGPA     float64 `json:"cumulativeGpa"`
+}
+
+// DetailedTranscriptResponse lists every course in chronological order
+type DetailedTranscriptResponse struct {
+	Courses []DetailedCourse `json:"courses"`
+}
+
+// GetDetailedTranscript returns the courses in semester order, keeping the
+// transcript order within a semester, each with the earned credits and CGPA so far.
+//
+//encore:api public method=GET path=/transcript/:userID/detailed
+func GetDetailedTranscript(ctx context.Context, userID string) (*DetailedTranscriptResponse, error) {
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DetailedTranscriptResponse{Courses: []DetailedCourse{}}
+	var taken []Course
+	for _, label := range semestersOf(transcript.Courses) {
+		for _, course := range GetCoursesBySemester(transcript.Courses, label) {
+			taken = append(taken, course)
+			summary := CalculateGPASummary(taken)
+			resp.Courses = append(resp.Courses, DetailedCourse{
+				Course:            course,
+				CumulativeCredits: summary.EarnedCredits,
+				CumulativeGPA:     summary.GPA,
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+// SemesterExtremesResponse represents the highest and lowest GPA semesters of a transcript.
+// Both are omitted when no completed semester has GPA-bearing courses.
+type SemesterExtremesResponse struct {
+	Highest *SemesterSummary `json:"highest,omitempty"`
+	Lowest  *SemesterSummary `json:"lowest,omitempty"`
+}
+
+//encore:api public method=GET path=/transcript/:userID/semester-extremes
+func GetSemesterExtremes(ctx context.Context, userID string) (*SemesterExtremesResponse, error) {
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	inProgress := make(map[string]bool)
+	for _, course := range transcript.Courses {
+		if course.InProgress || grades.IsInProgress(course.Grade) {
+			inProgress[course.Semester] = true
+		}
+	}
+
+	resp := &SemesterExtremesResponse{}
+	// Semesters are in chronological order, so strict comparisons keep the earlier semester on ties
+	report := BuildGPAReport(transcript.Courses)
+	for i := range report.Semesters {
+		sem := &report.Semesters[i]
+		if inProgress[sem.Semester] || sem.TotalCredits == 0 {
+			continue
+		}
+		if resp.Highest == nil || sem.GPA > resp.Highest.GPA {
+			resp.Highest = sem
+		}
+		if resp.Lowest == nil || sem.GPA < resp.Lowest.GPA {
+			resp.Lowest = sem
+		}
+	}
+
+	return resp, nil
+}
+
+// GPARequest optionally restricts the GPA to a contiguous range of semesters
+type GPARequest struct {
+	// First semester to include, e.g. "2021-2022 Güz Dönemi"; empty means the first semester
+	From string `query:"from"`
+	// Last semester to include; empty means the latest semester
+	To string `query:"to"`
+	// ExcludeSummer also reports the GPA ignoring summer terms (Yaz Okulu)
+	ExcludeSummer bool `query:"excludeSummer"`
+	// Places and Rounding control how the GPA is rounded (half_up, half_even or
+	// truncate); empty values follow the institution's convention
+	Places   int    `query:"places"`
+	Rounding string `query:"rounding"`
+}
+
+// GPAResponse represents the GPA over the requested semesters
+type GPAResponse struct {
+	GPASummary
+	Semesters []string `json:"semesters"`
+	// WithoutSummer is the GPA over the same range ignoring summer terms; only set when requested
+	WithoutSummer *GPASummary `json:"withoutSummer,omitempty"`
+	// UnknownGrades lists the courses left out of the GPA because their grade isn't recognized
+	UnknownGrades []Course `json:"unknownGrades"`
+}
+
+//encore:api public method=GET path=/transcript/:userID/gpa
+func GetGPA(ctx context.Context, userID string, req *GPARequest) (*GPAResponse, error) {
+	for _, bound := range []string{req.From, req.To} {
+		if _, ok := semester.Parse(bound); bound != "" && !ok {
+			return nil, &errs.Error{
+				Code:    errs.InvalidArgument,
+				Message: "unrecognized semester: " + bound,
+			}
+		}
+	}
+	if req.From != "" && req.To != "" && semester.Compare(req.From, req.To) > 0 {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "from must not come after to",
+		}
+	}
+
+	rounding, err := gpaRoundingFor(defaultProfile(), req.Places, req.Rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GPAResponse{Semesters: []string{}}
+	var courses []Course
+	for _, label := range semestersOf(transcript.Courses) {
+		if req.From != "" && semester.Compare(label, req.From) < 0 {
+			continue
+		}
+		if req.To != "" && semester.Compare(label, req.To) > 0 {
+			continue
+		}
+		resp.Semesters = append(resp.Semesters, label)
+		courses = append(courses, GetCoursesBySemester(transcript.Courses, label)...)
+	}
+
+	resp.GPASummary = rounding.RoundSummary(CalculateGPASummary(courses))
+	resp.UnknownGrades = UnknownGradeCourses(courses)
+	if req.ExcludeSummer {
+		withoutSummer := rounding.RoundSummary(CalculateGPASummaryExcluding(courses, isSummerCourse))
+		resp.WithoutSummer = &withoutSummer
+	}
+	return resp, nil
+}
+
+// isSummerCourse reports whether a course was taken in a summer term
+func isSummerCourse(course Course) bool {
+	key, ok := semester.Parse(course.Semester)
+	return ok && !key.Numbered() && key.Term == semester.TermSummer
+}
+
+// AcademicYearSummary is the credit and GPA rollup of one academic year
+type AcademicYearSummary struct {
+	// Year is the academic year ("2021-2022"), or "N. Yıl" for numbered semesters
+	Year          string   `json:"year"`
+	Semesters     []string `json:"semesters"`
+	EarnedCredits float64  `json:"earnedCredits"`
+	GPA           float64  `json:"gpa"`
+	GPACredits    float64  `json:"gpaCredits"`
+}
+
+// YearlySummaryResponse lists the academic years of a transcript in order
+type YearlySummaryResponse struct {
+	Years []AcademicYearSummary `json:"years"`
+	// Unrecognized lists semesters whose academic year couldn't be determined
+	Unrecognized []string `json:"unrecognized,omitempty"`
+}
+
+//encore:api public method=GET path=/transcript/:userID/yearly
+func GetYearlySummary(ctx context.Context, userID string) (*YearlySummaryResponse, error) {
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &YearlySummaryResponse{Years: []AcademicYearSummary{}}
+	var courses [][]Course
+	index := make(map[string]int)
+	for _, label := range semestersOf(transcript.Courses) {
+		key, ok := semester.Parse(label)
+		if !ok {
+			resp.Unrecognized = append(resp.Unrecognized, label)
+			continue
+		}
+
+		// Semesters are sorted, so years appear in chronological order
+		year := academicYear(key)
+		i, ok := index[year]
+		if !ok {
+			i = len(resp.Years)
+			index[year] = i
+			resp.Years = append(resp.Years, AcademicYearSummary{Year: year})
+			courses = append(courses, nil)
+		}
+		resp.Years[i].Semesters = append(resp.Years[i].Semesters, label)
+		courses[i] = append(courses[i], GetCoursesBySemester(transcript.Courses, label)...)
+	}
+
+	for i := range resp.Years {
+		summary := CalculateGPASummary(courses[i])
+		resp.Years[i].EarnedCredits = summary.EarnedCredits
+		resp.Years[i].GPA = summary.GPA
+		resp.Years[i].GPACredits = summary.TotalCredits
+	}
+
+	return resp, nil
+}
+
+// AcademicYearCoursesResponse holds the courses of one academic year with its totals
+type AcademicYearCoursesResponse struct {
+	GPASummary
+	Year      string   `json:"year"`
+	Semesters []string `json:"semesters"`
+	Courses   []Course `json:"courses"`
+}
+
+// academicYearPattern matches an academic year such as "2021-2022"
+var academicYearPattern = regexp.MustCompile(`^(\d{4})-(\d{4})$`)
+
+// GetAcademicYearCourses returns the courses of one academic year, including its
+// summer term (Yaz Okulu), with the year's GPA and credit totals
+//
+//encore:api public method=GET path=/transcript/:userID/year/:year
+func GetAcademicYearCourses(ctx context.Context, userID string, year string) (*AcademicYearCoursesResponse, error) {
+	if !isAcademicYear(year) {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "academic year must look like 2021-2022",
+		}
+	}
+
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &AcademicYearCoursesResponse{
+		Year:      year,
+		Semesters: []string{},
+		Courses:   []Course{},
+	}
+	for _, label := range semestersOf(transcript.Courses) {
+		key, ok := semester.Parse(label)
+		if !ok || key.Numbered() || academicYear(key) != year {
+			continue
+		}
+		resp.Semesters = append(resp.Semesters, label)
+		resp.Courses = append(resp.Courses, GetCoursesBySemester(transcript.Courses, label)...)
+	}
+	if len(resp.Courses) == 0 {
+		return nil, &errs.Error{
+			Code:    errs.NotFound,
+			Message: "no courses found for academic year " + year,
+		}
+	}
+
+	resp.GPASummary = CalculateGPASummary(resp.Courses)
+	return resp, nil
+}
+
+// isAcademicYear reports whether year names an academic year such as "2021-2022"
+func isAcademicYear(year string) bool {
+	match := academicYearPattern.FindStringSubmatch(year)
+	if match == nil {
+		return false
+	}
+	start, _ := strconv.Atoi(match[1])
+	end, _ := strconv.Atoi(match[2])
+	return end == start+1
+}
+
+// academicYear names the academic year a semester belongs to. Summer terms
+// (Yaz Okulu) share the start year of the fall and spring before them.
+func academicYear(key semester.Key) string {
+	if key.Numbered() {
+		return fmt.Sprintf("%d. Yıl", (key.Term+1)/2)
+	}
+	return fmt.Sprintf("%d-%d", key.StartYear, key.StartYear+1)
+}
+
+// trendDirection classifies the change in semester GPA over the last gpaTrendWindow semesters
+func trendDirection(points []GPATrendPoint) string {
+	if len(points) < 2 {
+		return TrendStable
+	}
+
+	window := points
+	if len(window) > gpaTrendWindow {
+		window = window[len(window)-gpaTrendWindow:]
+	}
+
+	change := window[len(window)-1].SemesterGPA - window[0].SemesterGPA
+	switch {
+	case change >= gpaTrendThreshold:
+		return TrendImproving
+	case change <= -gpaTrendThreshold:
+		return TrendDeclining
+	}
+	return TrendStable
+}
+
+// GPAScale is a named grade-to-point table
+type GPAScale struct {
+	Name   string             `json:"name"`
+	Points map[string]float64 `json:"points"`
+}
+
+// GPAScalesResponse lists the available grade scales
+type GPAScalesResponse struct {
+	Default string     `json:"default"`
+	Scales  []GPAScale `json:"scales"`
+}
+
+//encore:api public method=GET path=/gpa-scales
+func ListGPAScales(ctx context.Context) (*GPAScalesResponse, error) {
+	names := make([]string, 0, len(gradeScales))
+	for name := range gradeScales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resp := &GPAScalesResponse{Default: defaultProfile().GradeScale}
+	for _, name := range names {
+		resp.Scales = append(resp.Scales, GPAScale{
+			Name:   name,
+			Points: gradeScales[name],
+		})
+	}
+	return resp, nil
+}
+
+// MinorSummaryRequest lists the course codes belonging to a minor (yandal) or
+// double major (çift anadal) program
+type MinorSummaryRequest struct {
+	MinorCodes []string `json:"minorCodes"`
+}
+
+// MinorSummaryResponse reports main-program and minor figures side by side
+type MinorSummaryResponse struct {
+	Main         GPASummary `json:"main"`
+	Minor        GPASummary `json:"minor"`
+	MinorCourses []Course   `json:"minorCourses"`
+}
+
+//encore:api public method=POST path=/transcript/:userID/minor-summary
+func GetMinorSummary(ctx context.Context, userID string, req *MinorSummaryRequest) (*MinorSummaryResponse, error) {
+	if len(req.MinorCodes) == 0 {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "minorCodes cannot be empty",
+		}
+	}
+
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	isMinor := minorCourseMatcher(req.MinorCodes)
+	isMain := func(course Course) bool { return !isMinor(course) }
+
+	resp := &MinorSummaryResponse{MinorCourses: []Course{}}
+	for _, course := range transcript.Courses {
+		if isMinor(course) {
+			resp.MinorCourses = append(resp.MinorCourses, course)
+		}
+	}
+
+	resp.Main = CalculateGPASummaryExcluding(transcript.Courses, isMinor)
+	resp.Minor = CalculateGPASummaryExcluding(transcript.Courses, isMain)
+
+	return resp, nil
+}
+
+// minorCourseMatcher reports whether a course belongs to the minor listing
+// minorCodes. Codes are compared with coursecode.Matches like plan slots are,
+// so a language suffix the parser stripped from a Turkish-taught course, or one
+// left off the list, still matches.
+func minorCourseMatcher(minorCodes []string) func(Course) bool {
+	return func(course Course) bool {
+		for _, code := range minorCodes {
+			if coursecode.Matches(course.Code, code) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SimulateDropRequest identifies the course a student is considering withdrawing from
+type SimulateDropRequest struct {
+	Semester string `json:"semester"`
+	Code     string `json:"code"`
+}
+
+// SimulateDropResponse compares the current figures with those after the drop
+type SimulateDropResponse struct {
+	Course  Course     `json:"course"`
+	Current GPASummary `json:"current"`
+	// AfterDrop is the summary as if the course had never been taken
+	AfterDrop GPASummary `json:"afterDrop"`
+}
+
+// SimulateDrop reports the CGPA and credit totals a student would have without
+// the given course. Stored data is left untouched.
+//
+//encore:api public method=POST path=/transcript/:userID/simulate-drop
+func SimulateDrop(ctx context.Context, userID string, req *SimulateDropRequest) (*SimulateDropResponse, error) {
+	if req.Semester == "" || req.Code == "" {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "semester and code are required",
+		}
+	}
+
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := courseKey(Course{Semester: req.Semester, Code: req.Code})
+	isDropped := func(course Course) bool { return courseKey(course) == key }
+
+	resp := &SimulateDropResponse{}
+	found := false
+	for _, course := range transcript.Courses {
+		if isDropped(course) {
+			resp.Course = course
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, &errs.Error{
+			Code:    errs.NotFound,
+			Message: "course not found in transcript",
+		}
+	}
+
+	resp.Current = CalculateGPASummary(transcript.Courses)
+	resp.AfterDrop = CalculateGPASummaryExcluding(transcript.Courses, isDropped)
+	return resp, nil
+}
+
+// BackfillGPAResponse reports how many stored GPAs were recomputed
+type BackfillGPAResponse struct {
+	Updated int `json:"updated"`
+}
+
+// BackfillGPA recomputes the stored GPA of every transcript. It is used once
+// after adding the gpa column and whenever the grade scale changes.
+//
+//encore:api private method=POST path=/admin/backfill-gpa
+func BackfillGPA(ctx context.Context) (*BackfillGPAResponse, error) {
+	transcripts, err := GetAllTranscripts(ctx)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve transcripts",
+		}
+	}
+
+	resp := &BackfillGPAResponse{}
+	for _, transcript := range transcripts {
+		if err := SetTranscriptGPA(ctx, transcript.UserID, storedGPA(transcript.Courses)); err != nil {
+			return nil, &errs.Error{
+				Code:    errs.Internal,
+				Message: "failed to store GPA",
+			}
+		}
+		resp.Updated++
+	}
+	return resp, nil
+}
@@ -0,0 +1,67 @@
+package transcript
+
+import "testing"
+
+func TestMinorCourseMatcher(t *testing.T) {
+	isMinor := minorCourseMatcher([]string{"EKO 201E", "isl 311", "MAT 2"})
+
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"EKO 201E", true},
+		{"EKO201E", true},
+		{"EKO 201", true},   // suffix stripped from a Turkish-taught course
+		{"ISL 311E", true},  // listed without its suffix
+		{"EKO 201T", false}, // a different language section
+		{"EKO 2011", false}, // a prefix of another code is not a match
+		{"MAT 201", false},
+		{"BLG 101E", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := isMinor(Course{Code: tt.code}); got != tt.want {
+				t.Errorf("isMinor(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSummerCourse(t *testing.T) {
+	tests := []struct {
+		semester string
+		want     bool
+	}{
+		{"2022-2023 Yaz Okulu", true},
+		{"2022-2023 Yaz Dönemi", true},
+		{"2022-2023 Summer Term", true},
+		{"2022-2023 Güz Dönemi", false},
+		{"2022-2023 Bahar Dönemi", false},
+		{"3. Yarıyıl", false}, // numbered semesters have no summer term
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.semester, func(t *testing.T) {
+			if got := isSummerCourse(Course{Semester: tt.semester}); got != tt.want {
+				t.Errorf("isSummerCourse(%q) = %v, want %v", tt.semester, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGPAExcludingSummer(t *testing.T) {
+	courses := []Course{
+		{Semester: "2022-2023 Güz Dönemi", Code: "MAT 103E", Credits: "4", Grade: "CC"},
+		{Semester: "2022-2023 Bahar Dönemi", Code: "FIZ 101E", Credits: "4", Grade: "CC"},
+		{Semester: "2022-2023 Yaz Okulu", Code: "MAT 104E", Credits: "4", Grade: "AA"},
+	}
+
+	with := CalculateGPASummary(courses)
+	without := CalculateGPASummaryExcluding(courses, isSummerCourse)
+	if !approxEqual(with.GPA, 8.0/3) || with.CourseCount != 3 {
+		t.Errorf("GPA with summer = %+v, want 2.67 over 3 courses", with)
+	}
+	if !approxEqual(without.GPA, 2.0) || without.CourseCount != 2 || !approxEqual(without.TotalCredits, 8) {
+		t.Errorf("GPA without summer = %+v, want 2.00 over 2 courses", without)
+	}
+}
@@ -0,0 +1,69 @@
+package transcript
+
+import (
+	"context"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+// TranscriptVersionInfo summarizes one stored transcript snapshot
+type TranscriptVersionInfo struct {
+	Version     int64     `json:"version"`
+	CreatedAt   time.Time `json:"createdAt"`
+	CourseCount int       `json:"courseCount"`
+}
+
+// TranscriptVersionsResponse lists the snapshots of a transcript, newest first
+type TranscriptVersionsResponse struct {
+	Versions []TranscriptVersionInfo `json:"versions"`
+}
+
+// ListTranscriptVersions lists the historical snapshots of a user's transcript.
+// Snapshots are only recorded while transcriptHistoryEnabled is set.
+//
+//encore:api public method=GET path=/transcript/:userID/versions
+func ListTranscriptVersions(ctx context.Context, userID string) (*TranscriptVersionsResponse, error) {
+	if userID == "" {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "user_id is required",
+		}
+	}
+
+	versions, err := GetTranscriptVersions(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve transcript versions",
+		}
+	}
+
+	return &TranscriptVersionsResponse{Versions: versions}, nil
+}
+
+//encore:api public method=GET path=/transcript/:userID/versions/:version
+func GetTranscriptSnapshot(ctx context.Context, userID string, version int64) (*GetTranscriptResponse, error) {
+	if userID == "" {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "user_id is required",
+		}
+	}
+
+	transcript, err := GetTranscriptVersion(ctx, userID, version)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve transcript version",
+		}
+	}
+	if transcript == nil {
+		return nil, &errs.Error{
+			Code:    errs.NotFound,
+			Message: "transcript version not found",
+		}
+	}
+
+	return &GetTranscriptResponse{Transcript: transcript}, nil
+}
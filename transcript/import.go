@@ -0,0 +1,165 @@
+package transcript
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"encore.dev/beta/errs"
+)
+
+// csvHeader is the column layout accepted by the CSV import
+var csvHeader = []string{"semester", "code", "name", "credits", "grade"}
+
+// ImportTranscriptCSVRequest represents the request for importing a transcript from CSV
+type ImportTranscriptCSVRequest struct {
+	UserID string `json:"userId"`
+	// CSV content with a semester,code,name,credits,grade header row
+	CSV string `json:"csv"`
+}
+
+// CSVRowError describes a malformed CSV line
+type CSVRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportTranscriptCSVResponse represents the result of a CSV import
+type ImportTranscriptCSVResponse struct {
+	Imported  int           `json:"imported"`
+	RowErrors []CSVRowError `json:"rowErrors,omitempty"`
+}
+
+//encore:api public method=POST path=/import-transcript-csv
+func ImportTranscriptCSV(ctx context.Context, req *ImportTranscriptCSVRequest) (*ImportTranscriptCSVResponse, error) {
+	if req.UserID == "" {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "userId is required",
+		}
+	}
+
+	courses, rowErrors, err := parseCoursesCSV(req.CSV)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: err.Error(),
+		}
+	}
+
+	// Nothing is stored unless every row is valid
+	if len(rowErrors) > 0 {
+		return &ImportTranscriptCSVResponse{RowErrors: rowErrors}, nil
+	}
+
+	if len(courses) == 0 {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "csv contains no courses",
+		}
+	}
+
+	if err := validateCourseCount(courses); err != nil {
+		return nil, err
+	}
+
+	if err := InsertTranscript(ctx, req.UserID, courses); err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to store transcript",
+		}
+	}
+
+	return &ImportTranscriptCSVResponse{Imported: len(courses)}, nil
+}
+
+// parseCoursesCSV parses CSV content into courses, collecting an error for each
+// malformed row. A missing or unexpected header is returned as err.
+func parseCoursesCSV(content string) ([]Course, []CSVRowError, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1 // Field counts are validated per row below
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, errors.New("csv is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	if len(header) != len(csvHeader) {
+		return nil, nil, fmt.Errorf("csv header must be %s", strings.Join(csvHeader, ","))
+	}
+	for i, column := range header {
+		if !strings.EqualFold(strings.TrimSpace(column), csvHeader[i]) {
+			return nil, nil, fmt.Errorf("csv header must be %s", strings.Join(csvHeader, ","))
+		}
+	}
+
+	var courses []Course
+	var rowErrors []CSVRowError
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, CSVRowError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		course, err := courseFromCSVRecord(record)
+		if err != nil {
+			rowErrors = append(rowErrors, CSVRowError{Row: row, Message: err.Error()})
+			continue
+		}
+		courses = append(courses, course)
+	}
+
+	return courses, rowErrors, nil
+}
+
+// courseFromCSVRecord validates a single CSV record and converts it to a Course
+func courseFromCSVRecord(record []string) (Course, error) {
+	if len(record) != len(csvHeader) {
+		return Course{}, fmt.Errorf("expected %d fields, got %d", len(csvHeader), len(record))
+	}
+
+	course := Course{
+		Semester: strings.TrimSpace(record[0]),
+		Code:     strings.TrimSpace(record[1]),
+		Name:     strings.TrimSpace(record[2]),
+		Credits:  strings.TrimSpace(record[3]),
+		Grade:    normalizeGrade(record[4]),
+	}
+
+	if course.Semester == "" {
+		return Course{}, errors.New("semester is required")
+	}
+	if err := validateCourse(course); err != nil {
+		return Course{}, err
+	}
+
+	return course, nil
+}
+
+// validateCourse checks that a course has a code, numeric credits and a known grade
+func validateCourse(course Course) error {
+	if course.Code == "" {
+		return errors.New("code is required")
+	}
+	if credits, err := strconv.ParseFloat(course.Credits, 64); err != nil || credits < 0 {
+		return fmt.Errorf("invalid credits %q", course.Credits)
+	}
+	if course.Exchange && course.Grade != "" {
+		return nil // Exchange courses keep their foreign grade
+	}
+	if !isKnownGrade(course.Grade) {
+		return fmt.Errorf("unknown grade %q", course.Grade)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package transcript
+
+import (
+	"regexp"
+
+	"encore.app/semester"
+	"encore.dev/beta/errs"
+)
+
+// InstitutionProfile gathers everything about an institution's transcripts the
+// service depends on: how the PDF is laid out, which grade scale applies and the
+// GPAs for honors standing. Supporting another university means adding a profile
+// to institutionProfiles rather than editing the parser.
+//
+// Parsing, PII stripping and the stateless GPA calculator use the profile of
+// the request. Stored transcripts don't record their institution, so the GPA
+// endpoints over stored data use defaultProfile. The repairs the parser makes to
+// individual rows (moving a Turkish course's letter suffix into its name, the
+// laboratory "L" suffix) remain specific to the ITU layout.
+type InstitutionProfile struct {
+	Name string
+	// DocumentSignatures are phrases printed on every transcript of the institution
+	DocumentSignatures []string
+	// SemesterPattern matches semester headings
+	SemesterPattern *regexp.Regexp
+	// AltSemesterPatterns are tried in order when SemesterPattern finds no heading
+	AltSemesterPatterns []*regexp.Regexp
+	// SummerSemesterPattern matches the headings of summer semesters, whose rows
+	// are laid out less predictably and are cleaned more conservatively
+	SummerSemesterPattern *regexp.Regexp
+	// ProgramPattern captures the program name printed in the transcript header
+	ProgramPattern *regexp.Regexp
+	// StudentNumberPattern captures the student number printed in the transcript header
+	StudentNumberPattern *regexp.Regexp
+	// CourseCodePattern matches the institution's own course codes; other codes
+	// in exchange sections are treated as foreign courses
+	CourseCodePattern *regexp.Regexp
+	// CourseCodes finds course codes in semester text
+	CourseCodes courseCodePatterns
+	// Rows reads the language, grade and credit columns of course rows
+	Rows rowPatterns
+
+	// ExchangeSectionPattern matches the heading that opens an exchange block.
+	// The block runs until the next semester heading.
+	ExchangeSectionPattern *regexp.Regexp
+	// ExchangeCoursePattern matches one foreign course row inside an exchange
+	// block. The named groups code, name, credits and grade are required;
+	// language is optional.
+	ExchangeCoursePattern *regexp.Regexp
+
+	// PIIPatterns match personal data that page headers and footers can leak into
+	// parsed course names: national ID and student numbers, name labels and
+	// verification codes
+	PIIPatterns []*regexp.Regexp
+
+	// Header and footer filters used while cleaning transcript text. They are
+	// regular expressions so institution-wide patterns (such as verification
+	// codes) can be expressed without hardcoding any individual's data.
+
+	// TableHeaderPatterns match course table header lines, skipped in every semester
+	TableHeaderPatterns []*regexp.Regexp
+	// SummaryLinePatterns match semester summary lines, skipped in regular semesters
+	SummaryLinePatterns []*regexp.Regexp
+	// PageLinePatterns match page header and footer lines, skipped in summer semesters
+	PageLinePatterns []*regexp.Regexp
+	// FooterPatterns mark where page footer content starts inside a course's text
+	FooterPatterns []*regexp.Regexp
+	// SummerFooterPatterns is the conservative footer list used for summer semesters
+	SummerFooterPatterns []*regexp.Regexp
+
+	// GradeScale names the entry of gradeScales GPAs are computed with
+	GradeScale string
+	// GPARounding is how the institution rounds the GPA printed on transcripts
+	GPARounding GPARounding
+	// HonorsGPAThreshold and HighHonorsGPAThreshold are the cumulative GPAs for
+	// honor and high honor standing
+	HonorsGPAThreshold     float64
+	HighHonorsGPAThreshold float64
+}
+
+// ituCourseCode matches an ITU course code such as "BLG 102E"
+const ituCourseCode = `[A-Z]{3}\s+\d{3}[A-Z]?`
+
+// ituProfile describes the not döküm belgesi of Istanbul Technical University
+var ituProfile = &InstitutionProfile{
+	Name: "itu",
+	DocumentSignatures: []string{
+		"NOT DÖKÜM BELGESİ",
+		"İSTANBUL TEKNİK ÜNİVERSİTESİ",
+	},
+	// Combines the patterns: regular semesters, Yaz Okulu, English headings
+	// ("2021-2022 Fall Semester") and numbered semesters ("1. Yarıyıl")
+	SemesterPattern: regexp.MustCompile(`(20\d{2}-20\d{2}\s+(Güz|Bahar|Yaz)\s+Dönemi|20\d{2}-20\d{2}\s+Yaz Okulu|20\d{2}-20\d{2}\s+(` +
+		semester.TermKeywordPattern() + `)\s+(` + englishTermSuffixes + `)|\d{1,2}\.\s*Yarıyıl)`),
+	AltSemesterPatterns: compilePatterns(
+		`(20\d{2}-20\d{2}\s+(`+semester.TermKeywordPattern()+`))`,
+		`(20\d{2}\s+(`+semester.TermKeywordPattern()+`))`,
+		`(Güz|Bahar|Yaz)\s+Dönemi`,
+		`(Yaz Okulu)`,
+	),
+	SummerSemesterPattern: regexp.MustCompile(`Yaz Okulu|Summer`),
+	ProgramPattern:        regexp.MustCompile(`(?m)(?:Programı|Program|Bölümü|Bölüm)\s*:\s*([^\n]+)`),
+	StudentNumberPattern:  regexp.MustCompile(`Öğrenci No\s*:?\s*(\d{6,12})`),
+	CourseCodePattern:     regexp.MustCompile(`^` + ituCourseCode + `$`),
+	CourseCodes: newCourseCodePatterns(
+		ituCourseCode,
+		`[A-Z]{3}\s+\d{3}[A-Z]*`,
+		`[A-Z]{2,4}\s+\d{2,4}[A-Z]?`,
+		`^[A-Z]{3}$`,
+		`^\d{3}[A-Z]?`,
+	),
+	// The language column reads "Tr" or "İng.", the latter sometimes without its dot
+	Rows: newRowPatterns(`Tr|İng\.?`, gradeAlternation),
+
+	ExchangeSectionPattern: regexp.MustCompile(`(?i)erasmus|değişim programı|exchange program`),
+	ExchangeCoursePattern:  regexp.MustCompile(`(?m)^\s*(?P<code>[A-Z]{2,6}[-.]?\s?\d{1,5}[A-Z]?)\s+(?P<name>\S.*?)\s+(?P<credits>\d{1,2}(?:[.,]\d+)?)\s+(?P<grade>[A-F][+-]?|[1-5][.,]\d|P|NP)(?:\s+(?P<language>[A-Za-zÇĞİÖŞÜçğıöşü]{2,}))?\s*$`),
+
+	PIIPatterns: compilePatterns(
+		`T\.C\.\s*Kimlik No\s*:?\s*\d*`,
+		`Öğrenci No\s*:?\s*\d*`,
+		`Adı\s*Soyadı\s*:?.*`,
+		`YOKTR[A-Z0-9]{8,}`, // e-Devlet document verification code
+		`\b\d{9,11}\b`,
+	),
+
+	TableHeaderPatterns: compilePatterns(
+		`Dersin Statüsü`, `Öğretim Dili`, `T U UK`, `AKTS`, `Not`, `Puan`, `Açıklama`,
+	),
+	SummaryLinePatterns: compilePatterns(
+		`DNO:`, `GNO:`, `TUK:`, `TAKTS:`, `DSD:`, `Başarılı`, `Pass`,
+	),
+	PageLinePatterns: compilePatterns(
+		`Öğrenci No`, `T\.C\. Kimlik No`, `Adı`, `Doğum Tarihi`, `Soyadı`,
+		`İSTANBUL TEKNİK ÜNİVERSİTESİ`, `NOT DÖKÜM BELGESİ`, `Belge Tarihi`, `YOKTR`,
+		`www\.turkiye\.gov\.tr`, `Bu belgenin doğruluğunu`, `SON SATIR`, `Bu satırdan sonra`,
+	),
+	FooterPatterns: compilePatterns(
+		`www\.turkiye\.gov\.tr`, `Öğrenci No`, `T\.C\. Kimlik No`, `Adı\s*Soyadı`,
+		`İSTANBUL TEKNİK ÜNİVERSİTESİ`, `NOT DÖKÜM BELGESİ`,
+		`YOKTR[A-Z0-9]{8,}`, // e-Devlet document verification code
+		`Ders kodunun başında \* olan dersler`,
+	),
+	SummerFooterPatterns: compilePatterns(
+		`www\.turkiye\.gov\.tr`, `NOT DÖKÜM BELGESİ`,
+		`YOKTR[A-Z0-9]{8,}`,
+		`Ders kodunun başında \* olan dersler`,
+	),
+
+	// ITU's official 4.00 scale, so computed GPAs match the official transcript
+	GradeScale:             "itu",
+	GPARounding:            GPARounding{Places: 2, Mode: RoundHalfUp},
+	HonorsGPAThreshold:     3.00, // Onur
+	HighHonorsGPAThreshold: 3.50, // Yüksek Onur
+}
+
+// institutionProfiles holds the supported institutions by name
+var institutionProfiles = map[string]*InstitutionProfile{
+	ituProfile.Name: ituProfile,
+}
+
+// lookupInstitution returns the named profile, or the configured default for an
+// empty name
+func lookupInstitution(name string) (*InstitutionProfile, error) {
+	if name == "" {
+		name = defaultInstitution
+	}
+	profile, ok := institutionProfiles[name]
+	if !ok {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "unknown institution: " + name,
+		}
+	}
+	return profile, nil
+}
+
+// defaultProfile returns the profile of the configured default institution. It
+// applies to computations over stored courses, which don't carry an institution.
+func defaultProfile() *InstitutionProfile {
+	return institutionProfiles[defaultInstitution]
+}
+
+// courseCodePatterns find course codes in semester text, from the strictest
+// pattern to the most forgiving one the parser falls back to
+type courseCodePatterns struct {
+	// Marked matches a code with an optional asterisk prefix, followed by a space
+	Marked *regexp.Regexp
+	// Bounded matches a code followed by a space or the end of the text
+	Bounded *regexp.Regexp
+	// Bare matches a code anywhere
+	Bare *regexp.Regexp
+	// Loose accepts any letter suffix after the course number
+	Loose *regexp.Regexp
+	// Flexible accepts department and course numbers of any plausible length
+	Flexible *regexp.Regexp
+	// Department and Number split a matched code into its well-formed parts
+	Department *regexp.Regexp
+	Number     *regexp.Regexp
+}
+
+// newCourseCodePatterns builds the course code patterns from the expression of
+// a well-formed code and its looser variants
+func newCourseCodePatterns(code, loose, flexible, department, number string) courseCodePatterns {
+	return courseCodePatterns{
+		Marked:     regexp.MustCompile(`(\*?\s*` + code + `)(?:\s|$)`),
+		Bounded:    regexp.MustCompile(`(` + code + `)(?:\s|$)`),
+		Bare:       regexp.MustCompile(`(` + code + `)`),
+		Loose:      regexp.MustCompile(loose),
+		Flexible:   regexp.MustCompile(`(` + flexible + `)`),
+		Department: regexp.MustCompile(department),
+		Number:     regexp.MustCompile(number),
+	}
+}
+
+// rowPatterns read the columns of a course row: the instruction language, the
+// T U UK AKTS numbers and the grade
+type rowPatterns struct {
+	// Grade matches a standalone grade. The guards on both sides keep grades
+	// inside longer tokens, such as "BA" in "DATABASE" or the "BA" of "BA+",
+	// from matching.
+	Grade *regexp.Regexp
+	// ExactGrade matches a column holding nothing but a grade
+	ExactGrade *regexp.Regexp
+	// LanguageMarker matches the language column at its tabular position: after
+	// the course name and right before the numeric columns. Requiring the digits
+	// and a non-letter before the marker keeps names such as "Transportation" or
+	// "İngilizce" from matching.
+	LanguageMarker *regexp.Regexp
+	// LanguageData matches a complete row: language, T U UK AKTS, grade and points
+	LanguageData *regexp.Regexp
+	// ECTSOnly matches a row whose UK column is blank: language, T U AKTS and grade
+	ECTSOnly *regexp.Regexp
+	// UKCredit matches the columns stuck together after the language, e.g. "İng.32488"
+	UKCredit *regexp.Regexp
+	// Credit matches the language followed by the first number
+	Credit *regexp.Regexp
+}
+
+// newRowPatterns builds the row patterns from the alternations of the language
+// markers and the letter grades
+func newRowPatterns(language, grade string) rowPatterns {
+	return rowPatterns{
+		Grade:          regexp.MustCompile(`(?:^|[^\p{L}])(` + grade + `)(?:[^\p{L}+]|$)`),
+		ExactGrade:     regexp.MustCompile(`^(?:` + grade + `)$`),
+		LanguageMarker: regexp.MustCompile(`(?:^|[^\p{L}])(` + language + `)\s*\d`),
+		LanguageData:   regexp.MustCompile(`(` + language + `)\s*(\d+)\s*(\d+)\s*(\d+\.?\d*)\s*(\d+\.?\d*)\s*(` + grade + `|` + markerAlternation + `|G|K|S|U)\s*(\d+\.?\d*)(?:\s*([A-Z]{2}|--))?`),
+		ECTSOnly:       regexp.MustCompile(`(` + language + `)\s*(\d+)\s+(\d+)\s+(\d+\.?\d*)\s+(` + grade + `|(?:` + markerAlternation + `)\b|[GKSU]\b)`),
+		UKCredit:       regexp.MustCompile(`(` + language + `)(\d)(\d)(\d(?:\.\d)?)(\d+(?:\.\d+)?)`),
+		Credit:         regexp.MustCompile(`(` + language + `)\s*([0-9]+\.?[0-9]*)`),
+	}
+}
@@ -0,0 +1,98 @@
+package transcript
+
+import "testing"
+
+// englishLanguageProfile is the ITU layout with the instruction language
+// printed as "Eng" instead of "İng."
+func englishLanguageProfile() *InstitutionProfile {
+	profile := *ituProfile
+	profile.Name = "itu-eng"
+	profile.Rows = newRowPatterns(`Tr|Eng`, gradeAlternation)
+	return &profile
+}
+
+func TestParseTranscriptTextUsesProfile(t *testing.T) {
+	const text = "2022-2023 Güz Dönemi\nBLG 335E Analysis of Algorithms I Eng 3 0 3 5 BB 3.00\n"
+
+	tests := []struct {
+		name    string
+		profile *InstitutionProfile
+		want    int
+	}{
+		{"itu layout skips the unknown language", ituProfile, 0},
+		{"profile language marker", englishLanguageProfile(), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			courses, _, err := parseTranscriptText(text, tt.profile)
+			if err != nil {
+				t.Fatalf("parseTranscriptText: %v", err)
+			}
+			if len(courses) != tt.want {
+				t.Fatalf("parsed %d courses, want %d: %+v", len(courses), tt.want, courses)
+			}
+			if tt.want > 0 && (courses[0].Code != "BLG 335E" || courses[0].Grade != "BB") {
+				t.Errorf("parsed %s %s, want BLG 335E BB", courses[0].Code, courses[0].Grade)
+			}
+		})
+	}
+}
+
+func TestBuildGPAReportUsesProfile(t *testing.T) {
+	gradeScales["test"] = map[string]float64{"AA": 5, "BB": 4}
+	defer delete(gradeScales, "test")
+
+	lenient := *ituProfile
+	lenient.GradeScale = "test"
+	lenient.HonorsGPAThreshold = 4.0
+	lenient.HighHonorsGPAThreshold = 5.0
+
+	courses := []Course{
+		{Semester: "2022-2023 Güz Dönemi", Code: "BLG 101E", Credits: "3", Grade: "AA"},
+		{Semester: "2022-2023 Güz Dönemi", Code: "MAT 103E", Credits: "3", Grade: "BB"},
+	}
+
+	tests := []struct {
+		name       string
+		profile    *InstitutionProfile
+		wantGPA    float64
+		wantHonors string
+	}{
+		{"itu scale", ituProfile, 3.5, HonorsHighHonor},
+		{"profile scale and thresholds", &lenient, 4.5, HonorsHonor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := buildGPAReport(courses, tt.profile)
+			if !approxEqual(report.Cumulative.GPA, tt.wantGPA) {
+				t.Errorf("cumulative GPA = %v, want %v", report.Cumulative.GPA, tt.wantGPA)
+			}
+			if len(report.Semesters) != 1 || !approxEqual(report.Semesters[0].GPA, tt.wantGPA) {
+				t.Errorf("semesters = %+v, want one with GPA %v", report.Semesters, tt.wantGPA)
+			}
+			if report.Honors != tt.wantHonors {
+				t.Errorf("honors = %q, want %q", report.Honors, tt.wantHonors)
+			}
+		})
+	}
+}
+
+func TestStripPIIText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"clean name", "Analysis of Algorithms I", "Analysis of Algorithms I"},
+		{"student number", "Analysis of Algorithms I Öğrenci No: 150190001", "Analysis of Algorithms I"},
+		{"verification code", "Physics I YOKTR1A2B3C4D5E", "Physics I"},
+		{"national ID", "Calculus 12345678901", "Calculus"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripPIIText(tt.in); got != tt.want {
+				t.Errorf("stripPIIText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+-- Student-defined course tags, kept apart from the parsed courses so re-parsing never wipes them
+CREATE TABLE course_tag (
+    user_id TEXT NOT NULL,
+    semester TEXT NOT NULL,
+    code TEXT NOT NULL,
+    tag TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, semester, code, tag)
+);
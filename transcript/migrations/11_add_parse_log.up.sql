@@ -0,0 +1,2 @@
+-- Keep the diagnostics of the parse that produced the stored courses, for support tickets
+ALTER TABLE transcript ADD COLUMN parse_log JSONB;
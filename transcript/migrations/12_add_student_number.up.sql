@@ -0,0 +1,2 @@
+-- Student number printed on the transcript, only kept when the uploader opts in
+ALTER TABLE transcript ADD COLUMN student_number TEXT;
@@ -0,0 +1,12 @@
+-- Scope idempotency keys to the user so two users sending the same key never
+-- see each other's responses, and remember a hash of the request body so a key
+-- reused for a different request is rejected instead of replayed. Stored keys
+-- only live for a day, so they are dropped rather than backfilled.
+DELETE FROM idempotency_key;
+
+ALTER TABLE idempotency_key
+    ADD COLUMN user_id TEXT NOT NULL,
+    ADD COLUMN request_hash TEXT NOT NULL;
+
+ALTER TABLE idempotency_key DROP CONSTRAINT idempotency_key_pkey;
+ALTER TABLE idempotency_key ADD PRIMARY KEY (endpoint, user_id, key);
@@ -0,0 +1,13 @@
+-- A transcript without courses that carry quality points has no GPA. Store NULL
+-- rather than 0 so program averages over the gpa column leave it out.
+UPDATE transcript t
+SET gpa = NULL
+WHERE gpa = 0
+  AND NOT EXISTS (
+    SELECT 1
+    FROM jsonb_array_elements(t.courses) AS c
+    WHERE c->>'grade' IN ('AA', 'BA+', 'BA', 'BB+', 'BB', 'CB+', 'CB', 'CC+', 'CC',
+                          'DC+', 'DC', 'DD+', 'DD', 'FD', 'FF', 'VF')
+      AND NOT COALESCE((c->>'exchange')::boolean, FALSE)
+      AND NOT COALESCE((c->>'in_progress')::boolean, FALSE)
+  );
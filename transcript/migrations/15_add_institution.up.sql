@@ -0,0 +1,3 @@
+-- Institution whose layout the stored transcript was parsed with, so reparses
+-- and appends use the same profile. Empty for transcripts stored before it was recorded.
+ALTER TABLE transcript ADD COLUMN institution TEXT;
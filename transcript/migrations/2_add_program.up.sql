@@ -0,0 +1,5 @@
+-- Store the academic program parsed from the transcript header
+ALTER TABLE transcript ADD COLUMN program TEXT;
+
+-- Create an index on program for cohort statistics
+CREATE INDEX idx_transcript_program ON transcript(program);
@@ -0,0 +1,5 @@
+-- Store the cumulative GPA so transcripts can be ranked without recomputing it
+ALTER TABLE transcript ADD COLUMN gpa DOUBLE PRECISION;
+
+-- Create an index on gpa for class-rank queries
+CREATE INDEX idx_transcript_gpa ON transcript(gpa);
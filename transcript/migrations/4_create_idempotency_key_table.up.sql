@@ -0,0 +1,13 @@
+-- Remember the responses of store requests so retries with the same
+-- Idempotency-Key don't repeat the work
+CREATE TABLE idempotency_key (
+    endpoint TEXT NOT NULL,
+    key TEXT NOT NULL,
+    response JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (endpoint, key)
+);
+
+-- Create an index on expires_at for purging expired keys
+CREATE INDEX idx_idempotency_key_expires_at ON idempotency_key(expires_at);
@@ -0,0 +1,8 @@
+-- Reference data for courses, used to correct values the parser gets wrong
+CREATE TABLE course_catalog (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT '',
+    credits DOUBLE PRECISION NOT NULL DEFAULT 0,
+    lesson_id TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
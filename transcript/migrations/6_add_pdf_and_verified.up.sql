@@ -0,0 +1,5 @@
+-- Keep the uploaded PDF so transcripts can be reparsed after parser fixes
+ALTER TABLE transcript ADD COLUMN pdf BYTEA;
+
+-- Verified transcripts have been checked by hand and are never reparsed
+ALTER TABLE transcript ADD COLUMN verified BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,14 @@
+-- Record every grade change so altered grades can be investigated
+CREATE TABLE course_audit (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    semester TEXT NOT NULL,
+    code TEXT NOT NULL,
+    old_grade TEXT NOT NULL,
+    new_grade TEXT NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    changed_by TEXT NOT NULL
+);
+
+-- Create an index on user_id for per-user audit lookups
+CREATE INDEX idx_course_audit_user_id ON course_audit(user_id);
@@ -0,0 +1,2 @@
+-- Version is bumped on every course write so concurrent updates can be detected
+ALTER TABLE transcript ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,8 @@
+-- Historical snapshots of a transcript, kept when transcriptHistoryEnabled is set
+CREATE TABLE transcript_version (
+    user_id TEXT NOT NULL,
+    version BIGINT NOT NULL,
+    courses JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, version)
+);
@@ -0,0 +1,71 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+
+	"encore.dev/beta/errs"
+)
+
+// SetCourseNoteRequest identifies a course and the note to set on it
+type SetCourseNoteRequest struct {
+	Semester string `json:"semester"`
+	Code     string `json:"code"`
+	// Note replaces the course's note; an empty note clears it
+	Note string `json:"note"`
+	// Version is the transcript version the client read; 0 skips the check
+	Version int64 `json:"version,omitempty"`
+}
+
+// SetCourseNoteResponse holds the annotated course
+type SetCourseNoteResponse struct {
+	Course Course `json:"course"`
+	// Version is the transcript version after the note was written
+	Version int64 `json:"version"`
+}
+
+// maxCourseNoteLength caps the length of a course note
+const maxCourseNoteLength = 1000
+
+//encore:api public method=PATCH path=/transcript/:userID/course/note
+func UpdateCourseNote(ctx context.Context, userID string, req *SetCourseNoteRequest) (*SetCourseNoteResponse, error) {
+	if req.Semester == "" || req.Code == "" {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "semester and code are required",
+		}
+	}
+	if len(req.Note) > maxCourseNoteLength {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "note is too long",
+		}
+	}
+
+	// Distinguish a missing transcript from a missing course
+	if _, err := loadTranscript(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	course, version, err := SetCourseNote(ctx, userID, req.Semester, req.Code, req.Note, req.Version)
+	if errors.Is(err, errVersionConflict) {
+		return nil, updateTranscriptError(err)
+	}
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to update course note",
+		}
+	}
+	if course == nil {
+		return nil, &errs.Error{
+			Code:    errs.NotFound,
+			Message: "course not found in transcript",
+		}
+	}
+
+	return &SetCourseNoteResponse{
+		Course:  *course,
+		Version: version,
+	}, nil
+}
@@ -4,38 +4,92 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
+
+	"encore.app/coursecode"
 	"encore.dev/storage/sqldb"
 )
 
 // InsertTranscript inserts a new transcript for a user
 func InsertTranscript(ctx context.Context, userID string, courses []Course) error {
+	defer transcripts.invalidate(userID)
+
+	existing, err := loadTranscriptRow(ctx, userID)
+	if err != nil {
+		return err
+	}
+	var stored []Course
+	if existing != nil {
+		stored = existing.Courses
+	}
+	courses = mergeStoredFields(stored, stripPII(normalizeCodes(courses)), time.Now())
+
 	coursesJSON, err := json.Marshal(courses)
 	if err != nil {
 		return err
 	}
 
-	_, err = transcriptdb.Exec(ctx, `
-		INSERT INTO transcript (user_id, courses)
-		VALUES ($1, $2)
+	gpa := storedGPA(courses)
+
+	// The row, its snapshot and its audits are written together or not at all
+	tx, err := transcriptdb.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var version int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO transcript (user_id, courses, gpa)
+		VALUES ($1, $2, $3)
 		ON CONFLICT (user_id) 
 		DO UPDATE SET 
 			courses = $2,
+			gpa = $3,
+			version = transcript.version + 1,
 			updated_at = NOW()
-	`, userID, coursesJSON)
-	
-	return err
+		RETURNING version
+	`, userID, coursesJSON, gpa).Scan(&version)
+	if err != nil {
+		return err
+	}
+
+	if err := saveSnapshot(ctx, tx, userID, version, coursesJSON); err != nil {
+		return err
+	}
+	if err := InsertCourseAudits(ctx, tx, gradeChanges(userID, stored, courses)); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// GetTranscriptByUserID retrieves a transcript for a specific user
+// GetTranscriptByUserID retrieves a transcript for a specific user, from the
+// transcript cache when possible
 func GetTranscriptByUserID(ctx context.Context, userID string) (*Transcript, error) {
+	if cached, ok := transcripts.get(userID, time.Now()); ok {
+		return cached, nil
+	}
+
+	generation := transcripts.generation(userID)
+	transcript, err := loadTranscriptRow(ctx, userID)
+	if err != nil || transcript == nil {
+		return nil, err
+	}
+	transcripts.put(userID, transcript, generation, time.Now())
+	return transcript, nil
+}
+
+// loadTranscriptRow reads a user's transcript from the database, bypassing the
+// cache. Writers use it so their checks never see a stale transcript.
+func loadTranscriptRow(ctx context.Context, userID string) (*Transcript, error) {
 	var transcript Transcript
 	var coursesJSON []byte
 
 	err := transcriptdb.QueryRow(ctx, `
-		SELECT id, user_id, courses
+		SELECT id, user_id, COALESCE(program, ''), COALESCE(institution, ''), courses, version
 		FROM transcript
 		WHERE user_id = $1
-	`, userID).Scan(&transcript.ID, &transcript.UserID, &coursesJSON)
+	`, userID).Scan(&transcript.ID, &transcript.UserID, &transcript.Program, &transcript.Institution, &coursesJSON, &transcript.Version)
 
 	if err != nil {
 		if errors.Is(err, sqldb.ErrNoRows) {
@@ -53,34 +107,114 @@ func GetTranscriptByUserID(ctx context.Context, userID string) (*Transcript, err
 	return &transcript, nil
 }
 
-// UpdateTranscriptByUserID updates an existing transcript for a user
-func UpdateTranscriptByUserID(ctx context.Context, userID string, courses []Course) error {
+// errVersionConflict is returned when a transcript changed after the caller read it
+var errVersionConflict = errors.New("transcript version conflict")
+
+// UpdateTranscriptByUserID updates an existing transcript for a user.
+// A non-zero expectedVersion must match the stored version; otherwise the version
+// read here guards against a concurrent write. Either mismatch returns errVersionConflict.
+func UpdateTranscriptByUserID(ctx context.Context, userID string, courses []Course, expectedVersion int64) (int64, error) {
+	defer transcripts.invalidate(userID)
+
+	existing, err := loadTranscriptRow(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if existing == nil {
+		return 0, errors.New("no transcript found for user")
+	}
+	if expectedVersion != 0 && expectedVersion != existing.Version {
+		return 0, errVersionConflict
+	}
+	courses = mergeStoredFields(existing.Courses, stripPII(normalizeCodes(courses)), time.Now())
+
+	return writeTranscriptCourses(ctx, existing, courses)
+}
+
+// writeTranscriptCourses replaces the courses of a transcript read with
+// loadTranscriptRow, recording the new version's snapshot and grade audits. The
+// write only applies if the stored version is still existing.Version, so a
+// concurrent write returns errVersionConflict instead of being overwritten.
+// All three writes share a transaction, so a failure leaves none of them behind.
+func writeTranscriptCourses(ctx context.Context, existing *Transcript, courses []Course) (int64, error) {
+	userID := existing.UserID
 	coursesJSON, err := json.Marshal(courses)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	result, err := transcriptdb.Exec(ctx, `
+	gpa := storedGPA(courses)
+
+	tx, err := transcriptdb.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(ctx, `
 		UPDATE transcript 
-		SET courses = $2, updated_at = NOW()
-		WHERE user_id = $1
-	`, userID, coursesJSON)
+		SET courses = $2, gpa = $3, version = version + 1, updated_at = NOW()
+		WHERE user_id = $1 AND version = $4
+	`, userID, coursesJSON, gpa, existing.Version)
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	rowsAffected := result.RowsAffected()
 
 	if rowsAffected == 0 {
-		return errors.New("no transcript found for user")
+		return 0, errVersionConflict
 	}
 
-	return nil
+	version := existing.Version + 1
+	if err := saveSnapshot(ctx, tx, userID, version, coursesJSON); err != nil {
+		return 0, err
+	}
+	if err := InsertCourseAudits(ctx, tx, gradeChanges(userID, existing.Courses, courses)); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// saveSnapshot records a transcript version in the history when transcriptHistoryEnabled is set
+func saveSnapshot(ctx context.Context, tx *sqldb.Tx, userID string, version int64, coursesJSON []byte) error {
+	if !transcriptHistoryEnabled {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO transcript_version (user_id, version, courses)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, version) DO NOTHING
+	`, userID, version, coursesJSON)
+
+	return err
 }
 
-// DeleteTranscriptByUserID deletes a transcript for a specific user
+// DeleteTranscriptByUserID deletes a transcript, its history and its course tags for a specific user
 func DeleteTranscriptByUserID(ctx context.Context, userID string) error {
+	defer transcripts.invalidate(userID)
+
+	_, err := transcriptdb.Exec(ctx, `
+		DELETE FROM transcript_version
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = transcriptdb.Exec(ctx, `
+		DELETE FROM course_tag
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return err
+	}
+
 	result, err := transcriptdb.Exec(ctx, `
 		DELETE FROM transcript
 		WHERE user_id = $1
@@ -99,10 +233,144 @@ func DeleteTranscriptByUserID(ctx context.Context, userID string) error {
 	return nil
 }
 
-// GetAllTranscripts retrieves all transcripts (useful for admin purposes)
+// SetTranscriptProgram records the academic program of a user's transcript
+func SetTranscriptProgram(ctx context.Context, userID string, program string) error {
+	defer transcripts.invalidate(userID)
+
+	_, err := transcriptdb.Exec(ctx, `
+		UPDATE transcript
+		SET program = $2
+		WHERE user_id = $1
+	`, userID, program)
+
+	return err
+}
+
+// SetTranscriptInstitution records the institution a user's transcript was parsed with
+func SetTranscriptInstitution(ctx context.Context, userID string, institution string) error {
+	defer transcripts.invalidate(userID)
+
+	_, err := transcriptdb.Exec(ctx, `
+		UPDATE transcript
+		SET institution = $2
+		WHERE user_id = $1
+	`, userID, institution)
+
+	return err
+}
+
+// storedGPA returns the cumulative GPA of courses for the gpa column, or nil
+// when no course carries quality points. Such a transcript has no GPA rather
+// than a 0.00 one, and is left out of aggregates over the column.
+func storedGPA(courses []Course) *float64 {
+	summary := CalculateGPASummary(courses)
+	if summary.TotalCredits == 0 {
+		return nil
+	}
+	return &summary.GPA
+}
+
+// SetTranscriptGPA records the cumulative GPA of a user's transcript; a nil
+// gpa records that the transcript has none
+func SetTranscriptGPA(ctx context.Context, userID string, gpa *float64) error {
+	_, err := transcriptdb.Exec(ctx, `
+		UPDATE transcript
+		SET gpa = $2
+		WHERE user_id = $1
+	`, userID, gpa)
+
+	return err
+}
+
+// GetProgramGPAStats counts the students of a program and averages their
+// stored GPAs. Students without a GPA are counted but left out of the average.
+func GetProgramGPAStats(ctx context.Context, program string) (students int, averageGPA float64, err error) {
+	err = transcriptdb.QueryRow(ctx, `
+		SELECT COUNT(*), COALESCE(AVG(gpa), 0)::float8
+		FROM transcript
+		WHERE program = $1
+	`, program).Scan(&students, &averageGPA)
+
+	return students, averageGPA, err
+}
+
+// GetProgramGradeDistribution counts how often each grade occurs across a program's transcripts
+func GetProgramGradeDistribution(ctx context.Context, program string) ([]GradeCount, error) {
+	rows, err := transcriptdb.Query(ctx, `
+		SELECT c->>'grade' AS grade, COUNT(*)
+		FROM transcript t, jsonb_array_elements(t.courses) AS c
+		WHERE t.program = $1
+		GROUP BY grade
+		ORDER BY grade
+	`, program)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	distribution := []GradeCount{}
+	for rows.Next() {
+		var gc GradeCount
+		if err := rows.Scan(&gc.Grade, &gc.Count); err != nil {
+			return nil, err
+		}
+		distribution = append(distribution, gc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return distribution, nil
+}
+
+// GetProgramAverageEarnedCredits averages the earned credits per student in a
+// program. Credits are summed with CalculateEarnedCredits rather than in SQL so
+// in-progress, withdrawn and failed exchange courses are left out the same way.
+func GetProgramAverageEarnedCredits(ctx context.Context, program string) (float64, error) {
+	rows, err := transcriptdb.Query(ctx, `
+		SELECT courses
+		FROM transcript
+		WHERE program = $1
+	`, program)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	total := 0.0
+	students := 0
+	for rows.Next() {
+		var coursesJSON []byte
+		if err := rows.Scan(&coursesJSON); err != nil {
+			return 0, err
+		}
+
+		var courses []Course
+		if err := json.Unmarshal(coursesJSON, &courses); err != nil {
+			return 0, err
+		}
+
+		total += CalculateEarnedCredits(courses)
+		students++
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	if students == 0 {
+		return 0, nil
+	}
+	return total / float64(students), nil
+}
+
+// GetAllTranscripts retrieves all transcripts (useful for admin purposes).
+// It returns an empty slice rather than nil when there are none, so the
+// transcripts serialize as [] instead of null.
 func GetAllTranscripts(ctx context.Context) ([]Transcript, error) {
 	rows, err := transcriptdb.Query(ctx, `
-		SELECT id, user_id, courses
+		SELECT id, user_id, COALESCE(program, ''), COALESCE(institution, ''), courses, version
 		FROM transcript
 		ORDER BY created_at DESC
 	`)
@@ -111,12 +379,12 @@ func GetAllTranscripts(ctx context.Context) ([]Transcript, error) {
 	}
 	defer rows.Close()
 
-	var transcripts []Transcript
+	transcripts := []Transcript{}
 	for rows.Next() {
 		var transcript Transcript
 		var coursesJSON []byte
 
-		err := rows.Scan(&transcript.ID, &transcript.UserID, &coursesJSON)
+		err := rows.Scan(&transcript.ID, &transcript.UserID, &transcript.Program, &transcript.Institution, &coursesJSON, &transcript.Version)
 		if err != nil {
 			return nil, err
 		}
@@ -135,4 +403,507 @@ func GetAllTranscripts(ctx context.Context) ([]Transcript, error) {
 	}
 
 	return transcripts, nil
-} 
\ No newline at end of file
+}
+
+// courseKey identifies a course within a transcript
+func courseKey(course Course) string {
+	return course.Semester + "|" + coursecode.Normalize(course.Code)
+}
+
+// normalizeCodes returns a copy of courses with canonical course codes, unless
+// normalizeCourseCodesOnStore is turned off
+func normalizeCodes(courses []Course) []Course {
+	if !normalizeCourseCodesOnStore {
+		return courses
+	}
+
+	normalized := make([]Course, len(courses))
+	for i, course := range courses {
+		course.Code = coursecode.Normalize(course.Code)
+		normalized[i] = course
+	}
+	return normalized
+}
+
+// mergeStoredFields returns a copy of incoming carrying over the fields that
+// parsing can't produce. AddedAt keeps the timestamp of matching stored courses
+// and uses now for new ones; Note keeps the stored note unless incoming sets one.
+func mergeStoredFields(existing, incoming []Course, now time.Time) []Course {
+	addedAt := make(map[string]*time.Time)
+	notes := make(map[string]string)
+	for _, course := range existing {
+		key := courseKey(course)
+		if _, ok := addedAt[key]; !ok && course.AddedAt != nil {
+			addedAt[key] = course.AddedAt
+		}
+		if _, ok := notes[key]; !ok && course.Note != "" {
+			notes[key] = course.Note
+		}
+	}
+
+	merged := make([]Course, len(incoming))
+	for i, course := range incoming {
+		key := courseKey(course)
+		if ts, ok := addedAt[key]; ok {
+			course.AddedAt = ts
+		} else if course.AddedAt == nil {
+			ts := now
+			course.AddedAt = &ts
+		}
+		if course.Note == "" {
+			course.Note = notes[key]
+		}
+		merged[i] = course
+	}
+	return merged
+}
+
+// SetCourseNote sets the note of the course with the given semester and code in
+// a user's transcript, leaving every other field untouched. A non-zero
+// expectedVersion must match the stored version, and a concurrent write returns
+// errVersionConflict. It returns the updated course and the new transcript
+// version, or a nil course if the transcript has no such course.
+func SetCourseNote(ctx context.Context, userID, semester, code, note string, expectedVersion int64) (*Course, int64, error) {
+	defer transcripts.invalidate(userID)
+
+	transcript, err := loadTranscriptRow(ctx, userID)
+	if err != nil || transcript == nil {
+		return nil, 0, err
+	}
+	if expectedVersion != 0 && expectedVersion != transcript.Version {
+		return nil, 0, errVersionConflict
+	}
+
+	courses := make([]Course, len(transcript.Courses))
+	copy(courses, transcript.Courses)
+
+	var updated *Course
+	for i := range courses {
+		course := &courses[i]
+		if course.Semester == semester && coursecode.Equal(course.Code, code) {
+			course.Note = note
+			updated = course
+			break
+		}
+	}
+	if updated == nil {
+		return nil, 0, nil
+	}
+
+	// Notes bypass mergeStoredFields, which would keep a note being cleared
+	version, err := writeTranscriptCourses(ctx, transcript, courses)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return updated, version, nil
+}
+
+// errIdempotencyKeyReused is returned when an idempotency key is sent again with
+// a different request body
+var errIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
+
+// GetIdempotentResponse loads the response stored for a user's unexpired
+// idempotency key into resp, reporting whether one was found. It returns
+// errIdempotencyKeyReused when the key was stored for a different request hash.
+func GetIdempotentResponse(ctx context.Context, endpoint, userID, key, requestHash string, resp any) (bool, error) {
+	var responseJSON []byte
+	var storedHash string
+	err := transcriptdb.QueryRow(ctx, `
+		SELECT response, request_hash
+		FROM idempotency_key
+		WHERE endpoint = $1 AND user_id = $2 AND key = $3 AND expires_at > NOW()
+	`, endpoint, userID, key).Scan(&responseJSON, &storedHash)
+
+	if err != nil {
+		if errors.Is(err, sqldb.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	if storedHash != requestHash {
+		return false, errIdempotencyKeyReused
+	}
+
+	return true, json.Unmarshal(responseJSON, resp)
+}
+
+// SaveIdempotentResponse stores the response of a user's request under its
+// idempotency key and request hash until ttl passes, purging keys that have
+// already expired
+func SaveIdempotentResponse(ctx context.Context, endpoint, userID, key, requestHash string, resp any, ttl time.Duration) error {
+	responseJSON, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	_, err = transcriptdb.Exec(ctx, `
+		DELETE FROM idempotency_key
+		WHERE expires_at <= NOW()
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = transcriptdb.Exec(ctx, `
+		INSERT INTO idempotency_key (endpoint, user_id, key, request_hash, response, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (endpoint, user_id, key)
+		DO UPDATE SET
+			request_hash = $4,
+			response = $5,
+			expires_at = $6
+	`, endpoint, userID, key, requestHash, responseJSON, time.Now().Add(ttl))
+
+	return err
+}
+
+// UpsertCatalogCourses inserts or replaces course catalog entries
+func UpsertCatalogCourses(ctx context.Context, courses []CatalogCourse) error {
+	tx, err := transcriptdb.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, course := range courses {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO course_catalog (code, name, credits, lesson_id)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (code)
+			DO UPDATE SET
+				name = $2,
+				credits = $3,
+				lesson_id = $4,
+				updated_at = NOW()
+		`, coursecode.Normalize(course.Code), course.Name, course.Credits, course.LessonID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetCatalogCourses retrieves the catalog entries for the given course codes, keyed by code
+func GetCatalogCourses(ctx context.Context, codes []string) (map[string]CatalogCourse, error) {
+	normalized := make([]string, len(codes))
+	for i, code := range codes {
+		normalized[i] = coursecode.Normalize(code)
+	}
+
+	rows, err := transcriptdb.Query(ctx, `
+		SELECT code, name, credits, lesson_id
+		FROM course_catalog
+		WHERE code = ANY($1)
+	`, normalized)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	catalog := make(map[string]CatalogCourse)
+	for rows.Next() {
+		var course CatalogCourse
+		if err := rows.Scan(&course.Code, &course.Name, &course.Credits, &course.LessonID); err != nil {
+			return nil, err
+		}
+		catalog[coursecode.Normalize(course.Code)] = course
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return catalog, nil
+}
+
+// SetTranscriptPDF stores the PDF a user's transcript was parsed from. A new PDF
+// hasn't been checked by hand, so the transcript is no longer verified.
+func SetTranscriptPDF(ctx context.Context, userID string, pdf []byte) error {
+	_, err := transcriptdb.Exec(ctx, `
+		UPDATE transcript
+		SET pdf = $2, verified = FALSE
+		WHERE user_id = $1
+	`, userID, pdf)
+
+	return err
+}
+
+// SetTranscriptVerified marks a user's transcript as checked by hand, which
+// keeps reparses from overwriting it. Storing a new PDF clears the mark.
+func SetTranscriptVerified(ctx context.Context, userID string, verified bool) error {
+	result, err := transcriptdb.Exec(ctx, `
+		UPDATE transcript
+		SET verified = $2
+		WHERE user_id = $1
+	`, userID, verified)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("no transcript found for user")
+	}
+	return nil
+}
+
+// GetTranscriptPDF retrieves the stored PDF of a user's transcript, or nil if none was kept
+func GetTranscriptPDF(ctx context.Context, userID string) ([]byte, error) {
+	var pdf []byte
+	err := transcriptdb.QueryRow(ctx, `
+		SELECT pdf
+		FROM transcript
+		WHERE user_id = $1
+	`, userID).Scan(&pdf)
+
+	if err != nil {
+		if errors.Is(err, sqldb.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return pdf, nil
+}
+
+// SetTranscriptStudentNumber records the student number printed on a user's transcript
+func SetTranscriptStudentNumber(ctx context.Context, userID string, studentNumber string) error {
+	_, err := transcriptdb.Exec(ctx, `
+		UPDATE transcript
+		SET student_number = $2
+		WHERE user_id = $1
+	`, userID, studentNumber)
+
+	return err
+}
+
+// SetTranscriptParseLog records the diagnostics of the parse that produced a
+// user's stored courses
+func SetTranscriptParseLog(ctx context.Context, userID string, diagnostics *ParseDiagnostics) error {
+	logJSON, err := json.Marshal(diagnostics)
+	if err != nil {
+		return err
+	}
+
+	_, err = transcriptdb.Exec(ctx, `
+		UPDATE transcript
+		SET parse_log = $2
+		WHERE user_id = $1
+	`, userID, logJSON)
+
+	return err
+}
+
+// GetTranscriptParseLog retrieves the stored parse diagnostics of a user's
+// transcript, or nil if none were kept
+func GetTranscriptParseLog(ctx context.Context, userID string) (*ParseDiagnostics, error) {
+	var logJSON []byte
+	err := transcriptdb.QueryRow(ctx, `
+		SELECT parse_log
+		FROM transcript
+		WHERE user_id = $1
+	`, userID).Scan(&logJSON)
+
+	if err != nil {
+		if errors.Is(err, sqldb.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if logJSON == nil {
+		return nil, nil
+	}
+
+	var diagnostics ParseDiagnostics
+	if err := json.Unmarshal(logJSON, &diagnostics); err != nil {
+		return nil, err
+	}
+	return &diagnostics, nil
+}
+
+// StoredPDFRef identifies a transcript that has its source PDF stored
+type StoredPDFRef struct {
+	UserID   string
+	Verified bool
+}
+
+// GetStoredPDFRefs lists the transcripts that have their source PDF stored
+func GetStoredPDFRefs(ctx context.Context) ([]StoredPDFRef, error) {
+	rows, err := transcriptdb.Query(ctx, `
+		SELECT user_id, verified
+		FROM transcript
+		WHERE pdf IS NOT NULL
+		ORDER BY user_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refs := []StoredPDFRef{}
+	for rows.Next() {
+		var ref StoredPDFRef
+		if err := rows.Scan(&ref.UserID, &ref.Verified); err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// InsertCourseAudits records grade changes in the audit log as part of tx
+func InsertCourseAudits(ctx context.Context, tx *sqldb.Tx, audits []CourseAudit) error {
+	for _, audit := range audits {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO course_audit (user_id, semester, code, old_grade, new_grade, changed_by)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, audit.UserID, audit.Semester, audit.Code, audit.OldGrade, audit.NewGrade, audit.ChangedBy)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCourseAudits retrieves a user's grade change log, newest first
+func GetCourseAudits(ctx context.Context, userID string) ([]CourseAudit, error) {
+	rows, err := transcriptdb.Query(ctx, `
+		SELECT user_id, semester, code, old_grade, new_grade, changed_at, changed_by
+		FROM course_audit
+		WHERE user_id = $1
+		ORDER BY changed_at DESC, id DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	audits := []CourseAudit{}
+	for rows.Next() {
+		var audit CourseAudit
+		err := rows.Scan(&audit.UserID, &audit.Semester, &audit.Code, &audit.OldGrade,
+			&audit.NewGrade, &audit.ChangedAt, &audit.ChangedBy)
+		if err != nil {
+			return nil, err
+		}
+		audits = append(audits, audit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return audits, nil
+}
+
+// GetTranscriptVersions lists the stored snapshots of a user's transcript, newest first
+func GetTranscriptVersions(ctx context.Context, userID string) ([]TranscriptVersionInfo, error) {
+	rows, err := transcriptdb.Query(ctx, `
+		SELECT version, created_at, jsonb_array_length(courses)
+		FROM transcript_version
+		WHERE user_id = $1
+		ORDER BY version DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []TranscriptVersionInfo{}
+	for rows.Next() {
+		var info TranscriptVersionInfo
+		if err := rows.Scan(&info.Version, &info.CreatedAt, &info.CourseCount); err != nil {
+			return nil, err
+		}
+		versions = append(versions, info)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// GetTranscriptVersion retrieves one snapshot of a user's transcript, or nil if it doesn't exist
+func GetTranscriptVersion(ctx context.Context, userID string, version int64) (*Transcript, error) {
+	transcript := Transcript{UserID: userID, Version: version}
+	var coursesJSON []byte
+
+	err := transcriptdb.QueryRow(ctx, `
+		SELECT courses
+		FROM transcript_version
+		WHERE user_id = $1 AND version = $2
+	`, userID, version).Scan(&coursesJSON)
+	if err != nil {
+		if errors.Is(err, sqldb.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(coursesJSON, &transcript.Courses); err != nil {
+		return nil, err
+	}
+
+	return &transcript, nil
+}
+
+// InsertCourseTag tags a course; tagging a course twice with the same tag is a no-op
+func InsertCourseTag(ctx context.Context, userID string, tag CourseTag) error {
+	_, err := transcriptdb.Exec(ctx, `
+		INSERT INTO course_tag (user_id, semester, code, tag)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`, userID, tag.Semester, coursecode.Normalize(tag.Code), tag.Tag)
+
+	return err
+}
+
+// DeleteCourseTag removes a tag from a course, reporting whether it was set
+func DeleteCourseTag(ctx context.Context, userID string, tag CourseTag) (bool, error) {
+	result, err := transcriptdb.Exec(ctx, `
+		DELETE FROM course_tag
+		WHERE user_id = $1 AND semester = $2 AND code = $3 AND tag = $4
+	`, userID, tag.Semester, coursecode.Normalize(tag.Code), tag.Tag)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// GetCourseTags retrieves every course tag of a user
+func GetCourseTags(ctx context.Context, userID string) ([]CourseTag, error) {
+	rows, err := transcriptdb.Query(ctx, `
+		SELECT semester, code, tag
+		FROM course_tag
+		WHERE user_id = $1
+		ORDER BY semester, code, tag
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []CourseTag{}
+	for rows.Next() {
+		var tag CourseTag
+		if err := rows.Scan(&tag.Semester, &tag.Code, &tag.Tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
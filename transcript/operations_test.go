@@ -0,0 +1,169 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// storeTestTranscript stores a one-course transcript for userID and returns it
+func storeTestTranscript(t *testing.T, ctx context.Context, userID string) *Transcript {
+	t.Helper()
+	courses := []Course{{Semester: "2021-2022 Güz Dönemi", Code: "MAT 103E", Name: "Mathematics I", Credits: "4", Grade: "BB"}}
+	if err := InsertTranscript(ctx, userID, courses); err != nil {
+		t.Fatalf("InsertTranscript: %v", err)
+	}
+	stored, err := loadTranscriptRow(ctx, userID)
+	if err != nil || stored == nil {
+		t.Fatalf("loadTranscriptRow: %v", err)
+	}
+	return stored
+}
+
+func TestUpdateTranscriptVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	stored := storeTestTranscript(t, ctx, "version-conflict-user")
+
+	first := []Course{{Semester: "2021-2022 Güz Dönemi", Code: "MAT 103E", Name: "Mathematics I", Credits: "4", Grade: "BA"}}
+	if _, err := UpdateTranscriptByUserID(ctx, stored.UserID, first, stored.Version); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+
+	second := []Course{{Semester: "2021-2022 Güz Dönemi", Code: "MAT 103E", Name: "Mathematics I", Credits: "4", Grade: "AA"}}
+	if _, err := UpdateTranscriptByUserID(ctx, stored.UserID, second, stored.Version); !errors.Is(err, errVersionConflict) {
+		t.Errorf("update with stale version: got %v, want errVersionConflict", err)
+	}
+	if _, _, err := SetCourseNote(ctx, stored.UserID, "2021-2022 Güz Dönemi", "MAT 103E", "note", stored.Version); !errors.Is(err, errVersionConflict) {
+		t.Errorf("note with stale version: got %v, want errVersionConflict", err)
+	}
+
+	current, err := loadTranscriptRow(ctx, stored.UserID)
+	if err != nil {
+		t.Fatalf("loadTranscriptRow: %v", err)
+	}
+	if got := current.Courses[0].Grade; got != "BA" {
+		t.Errorf("stored grade = %q, want the first update's BA", got)
+	}
+}
+
+func TestConcurrentUpdatesConflict(t *testing.T) {
+	ctx := context.Background()
+	stored := storeTestTranscript(t, ctx, "concurrent-update-user")
+
+	const writers = 8
+	var wg sync.WaitGroup
+	results := make([]error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			courses := []Course{{Semester: "2021-2022 Güz Dönemi", Code: "MAT 103E", Name: "Mathematics I", Credits: "4", Grade: "CC"}}
+			_, results[i] = UpdateTranscriptByUserID(ctx, stored.UserID, courses, stored.Version)
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range results {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, errVersionConflict):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d of %d concurrent updates succeeded, want exactly 1", succeeded, writers)
+	}
+
+	current, err := loadTranscriptRow(ctx, stored.UserID)
+	if err != nil {
+		t.Fatalf("loadTranscriptRow: %v", err)
+	}
+	if current.Version != stored.Version+1 {
+		t.Errorf("version = %d, want %d", current.Version, stored.Version+1)
+	}
+}
+
+func TestStoredGPA(t *testing.T) {
+	tests := []struct {
+		name    string
+		courses []Course
+		want    *float64
+	}{
+		{"no courses", nil, nil},
+		{
+			name: "only pass/fail and in-progress courses",
+			courses: []Course{
+				{Code: "KIM 101E", Credits: "2", Grade: "BL"},
+				{Code: "MAT 103E", Credits: "4", Grade: "--"},
+			},
+			want: nil,
+		},
+		{
+			name:    "failed courses have a 0.00 GPA",
+			courses: []Course{{Code: "MAT 103E", Credits: "4", Grade: "FF"}},
+			want:    ptr(0.0),
+		},
+		{
+			name: "graded courses",
+			courses: []Course{
+				{Code: "MAT 103E", Credits: "4", Grade: "AA"},
+				{Code: "FIZ 101E", Credits: "4", Grade: "CC"},
+			},
+			want: ptr(3.0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := storedGPA(tt.courses)
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil:
+				t.Errorf("storedGPA() = %v, want %v", got, tt.want)
+			case !approxEqual(*got, *tt.want):
+				t.Errorf("storedGPA() = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeCodes(t *testing.T) {
+	courses := []Course{
+		{Code: "BLG102E", Grade: "AA"},
+		{Code: "mat  103e", Grade: "BB"},
+		{Code: "FIZ 101E", Grade: "CC"},
+	}
+	want := []string{"BLG 102E", "MAT 103E", "FIZ 101E"}
+
+	tests := []struct {
+		name    string
+		enabled bool
+		want    []string
+	}{
+		{"enabled", true, want},
+		{"disabled", false, []string{"BLG102E", "mat  103e", "FIZ 101E"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(enabled bool) { normalizeCourseCodesOnStore = enabled }(normalizeCourseCodesOnStore)
+			normalizeCourseCodesOnStore = tt.enabled
+
+			normalized := normalizeCodes(courses)
+			for i, course := range normalized {
+				if course.Code != tt.want[i] || course.Grade != courses[i].Grade {
+					t.Errorf("course %d = %+v, want code %q", i, course, tt.want[i])
+				}
+			}
+			if courses[0].Code != "BLG102E" {
+				t.Errorf("normalizeCodes modified its input: %+v", courses[0])
+			}
+		})
+	}
+}
+
+// ptr returns a pointer to a copy of v
+func ptr[T any](v T) *T {
+	return &v
+}
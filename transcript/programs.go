@@ -0,0 +1,65 @@
+package transcript
+
+import (
+	"context"
+	"fmt"
+
+	"encore.dev/beta/errs"
+)
+
+// GradeCount is how many times a grade was given across a cohort
+type GradeCount struct {
+	Grade string `json:"grade"`
+	Count int    `json:"count"`
+}
+
+// ProgramStatsResponse represents aggregate statistics for a program cohort
+type ProgramStatsResponse struct {
+	Program              string       `json:"program"`
+	StudentCount         int          `json:"studentCount"`
+	AverageGPA           float64      `json:"averageGpa"`
+	AverageEarnedCredits float64      `json:"averageEarnedCredits"`
+	GradeDistribution    []GradeCount `json:"gradeDistribution"`
+}
+
+//encore:api public method=GET path=/programs/:program/stats
+func GetProgramStats(ctx context.Context, program string) (*ProgramStatsResponse, error) {
+	students, averageGPA, err := GetProgramGPAStats(ctx, program)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to compute program GPA",
+		}
+	}
+
+	if students < minProgramCohortSize {
+		return nil, &errs.Error{
+			Code:    errs.FailedPrecondition,
+			Message: fmt.Sprintf("program statistics require at least %d students", minProgramCohortSize),
+		}
+	}
+
+	resp := &ProgramStatsResponse{
+		Program:      program,
+		StudentCount: students,
+		AverageGPA:   averageGPA,
+	}
+
+	resp.AverageEarnedCredits, err = GetProgramAverageEarnedCredits(ctx, program)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to compute program credits",
+		}
+	}
+
+	resp.GradeDistribution, err = GetProgramGradeDistribution(ctx, program)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to compute grade distribution",
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,114 @@
+package transcript
+
+import (
+	"context"
+	"strconv"
+
+	"encore.app/coursecode"
+	"encore.dev/beta/errs"
+)
+
+// RecalculateRequest represents the request body
+type RecalculateRequest struct {
+	// Persist writes the recalculated courses back; otherwise the result is only previewed
+	Persist bool `json:"persist"`
+}
+
+// RecalculateChanges counts the repairs made by a recalculation
+type RecalculateChanges struct {
+	CodesNormalized    int `json:"codesNormalized"`
+	NamesFilled        int `json:"namesFilled"`
+	LessonIDsFilled    int `json:"lessonIdsFilled"`
+	CreditsFromCatalog int `json:"creditsFromCatalog"`
+}
+
+// RecalculateResponse holds the cleaned transcript with its GPA before and after
+type RecalculateResponse struct {
+	Transcript *Transcript        `json:"transcript"`
+	Before     GPASummary         `json:"before"`
+	After      GPASummary         `json:"after"`
+	Changes    RecalculateChanges `json:"changes"`
+	Persisted  bool               `json:"persisted"`
+}
+
+// RecalculateTranscript reruns the repair utilities over a stored transcript:
+// course codes are normalized, missing names and lesson IDs are filled in from
+// the catalog and zero credits are replaced with catalog credits. The cleaned
+// transcript and its fresh GPA are returned and, with persist set, stored.
+//
+//encore:api public method=POST path=/transcript/:userID/recalculate
+func RecalculateTranscript(ctx context.Context, userID string, req *RecalculateRequest) (*RecalculateResponse, error) {
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, len(transcript.Courses))
+	for _, course := range transcript.Courses {
+		codes = append(codes, course.Code)
+	}
+	catalog, err := GetCatalogCourses(ctx, codes)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve catalog",
+		}
+	}
+
+	courses, changes := recalculateCourses(transcript.Courses, catalog)
+	resp := &RecalculateResponse{
+		Before:  CalculateGPASummary(transcript.Courses),
+		After:   CalculateGPASummary(courses),
+		Changes: changes,
+	}
+
+	recalculated := *transcript
+	recalculated.Courses = courses
+	resp.Transcript = &recalculated
+
+	if !req.Persist || changes == (RecalculateChanges{}) {
+		return resp, nil
+	}
+
+	version, err := UpdateTranscriptByUserID(ctx, userID, courses, transcript.Version)
+	if err != nil {
+		return nil, updateTranscriptError(err)
+	}
+	recalculated.Version = version
+	resp.Persisted = true
+
+	return resp, nil
+}
+
+// recalculateCourses returns a repaired copy of courses using the catalog
+// entries keyed by normalized code, counting each kind of repair
+func recalculateCourses(courses []Course, catalog map[string]CatalogCourse) ([]Course, RecalculateChanges) {
+	var changes RecalculateChanges
+	repaired := make([]Course, len(courses))
+	for i, course := range courses {
+		if normalized := coursecode.Normalize(course.Code); normalized != course.Code {
+			course.Code = normalized
+			changes.CodesNormalized++
+		}
+
+		entry, ok := catalog[course.Code]
+		if ok {
+			if course.Name == "" && entry.Name != "" {
+				course.Name = entry.Name
+				changes.NamesFilled++
+			}
+			if course.LessonID == "" && entry.LessonID != "" {
+				course.LessonID = entry.LessonID
+				changes.LessonIDsFilled++
+			}
+			if hasZeroCredits(course) && entry.Credits > 0 {
+				course.Credits = strconv.FormatFloat(entry.Credits, 'f', -1, 64)
+				course.CreditsFromCatalog = true
+				changes.CreditsFromCatalog++
+			}
+		}
+
+		repaired[i] = course
+	}
+	return repaired, changes
+}
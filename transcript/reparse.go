@@ -0,0 +1,192 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"encore.dev/beta/errs"
+	"encore.dev/rlog"
+)
+
+// reparseProgressInterval is how many transcripts are processed between progress log lines
+const reparseProgressInterval = 50
+
+// ReparseAllRequest represents the request body
+type ReparseAllRequest struct {
+	// DryRun reports what would change without writing anything
+	DryRun bool `json:"dryRun"`
+	// MaxConcurrency limits how many PDFs are reparsed at once; defaults to the
+	// number of CPUs and is capped at maxBatchConcurrency
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+}
+
+// ReparseChange describes how reparsing changed one transcript
+type ReparseChange struct {
+	UserID  string `json:"userId"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+	Changed int    `json:"changed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ReparseAllResponse summarizes a reparse run
+type ReparseAllResponse struct {
+	DryRun    bool            `json:"dryRun"`
+	Processed int             `json:"processed"`
+	Updated   int             `json:"updated"`
+	Skipped   int             `json:"skipped"`
+	Failed    int             `json:"failed"`
+	Changes   []ReparseChange `json:"changes"`
+}
+
+// ReparseAll re-runs the current parser over every stored PDF and updates the
+// courses of transcripts whose parse result changed. Verified transcripts are skipped.
+//
+//encore:api private method=POST path=/admin/reparse-all
+func ReparseAll(ctx context.Context, req *ReparseAllRequest) (*ReparseAllResponse, error) {
+	if req.MaxConcurrency < 0 {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "maxConcurrency cannot be negative",
+		}
+	}
+
+	refs, err := GetStoredPDFRefs(ctx)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to list stored PDFs",
+		}
+	}
+
+	resp := &ReparseAllResponse{DryRun: req.DryRun, Changes: []ReparseChange{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchConcurrency(req.MaxConcurrency))
+	for _, ref := range refs {
+		if ref.Verified {
+			resp.Skipped++
+			continue
+		}
+
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			change, updated := reparseTranscript(ctx, userID, req.DryRun)
+
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Processed++
+			switch {
+			case change.Error != "":
+				resp.Failed++
+				resp.Changes = append(resp.Changes, change)
+			case change.Added+change.Removed+change.Changed > 0:
+				resp.Changes = append(resp.Changes, change)
+			}
+			if updated {
+				resp.Updated++
+			}
+			if resp.Processed%reparseProgressInterval == 0 {
+				rlog.Info("reparse progress", "processed", resp.Processed, "total", len(refs)-resp.Skipped)
+			}
+		}(ref.UserID)
+	}
+	wg.Wait()
+
+	rlog.Info("reparse finished", "processed", resp.Processed, "updated", resp.Updated,
+		"skipped", resp.Skipped, "failed", resp.Failed, "dryRun", req.DryRun)
+	return resp, nil
+}
+
+// reparseTranscript reparses one stored PDF, writing the new courses unless
+// dryRun is set. It reports whether the transcript was updated.
+func reparseTranscript(ctx context.Context, userID string, dryRun bool) (ReparseChange, bool) {
+	change := ReparseChange{UserID: userID}
+
+	pdf, err := GetTranscriptPDF(ctx, userID)
+	if err != nil || pdf == nil {
+		change.Error = "failed to load stored PDF"
+		return change, false
+	}
+	existing, err := GetTranscriptByUserID(ctx, userID)
+	if err != nil || existing == nil {
+		change.Error = "failed to load transcript"
+		return change, false
+	}
+
+	// The stored PDF is parsed as is rather than round-tripped through base64
+	parsed, err := parseTranscriptPDF(ctx, &ParseTranscriptRequest{
+		Institution: existing.Institution,
+	}, pdf)
+	if err != nil {
+		change.Error = err.Error()
+		return change, false
+	}
+	if parsed.Error != "" {
+		change.Error = parsed.Error
+		return change, false
+	}
+
+	courses := normalizeCodes(toCourses(parsed.Courses))
+	change.Added, change.Removed, change.Changed = diffCourses(existing.Courses, courses)
+	if dryRun || change.Added+change.Removed+change.Changed == 0 {
+		return change, false
+	}
+
+	_, err = UpdateTranscriptByUserID(ctx, userID, courses, existing.Version)
+	if errors.Is(err, errVersionConflict) {
+		change.Error = "transcript was modified during the reparse; rerun to retry"
+		return change, false
+	}
+	if err != nil {
+		change.Error = "failed to update transcript"
+		return change, false
+	}
+	if parsed.Program != "" {
+		if err := SetTranscriptProgram(ctx, userID, parsed.Program); err != nil {
+			change.Error = "failed to store program"
+			return change, true
+		}
+	}
+	if parsed.Diagnostics != nil {
+		if err := SetTranscriptParseLog(ctx, userID, parsed.Diagnostics); err != nil {
+			change.Error = "failed to store parse log"
+			return change, true
+		}
+	}
+	return change, true
+}
+
+// diffCourses counts the courses added to, removed from and changed between
+// two versions of a transcript, matching courses by semester and code
+func diffCourses(before, after []Course) (added, removed, changed int) {
+	old := make(map[string]Course, len(before))
+	for _, course := range before {
+		old[courseKey(course)] = course
+	}
+
+	seen := make(map[string]bool, len(after))
+	for _, course := range after {
+		key := courseKey(course)
+		seen[key] = true
+		previous, ok := old[key]
+		switch {
+		case !ok:
+			added++
+		case previous.Name != course.Name || previous.Credits != course.Credits || previous.Grade != course.Grade:
+			changed++
+		}
+	}
+
+	for key := range old {
+		if !seen[key] {
+			removed++
+		}
+	}
+	return added, removed, changed
+}
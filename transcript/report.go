@@ -0,0 +1,113 @@
+package transcript
+
+import (
+	"context"
+	"fmt"
+
+	"encore.dev/beta/errs"
+)
+
+// Honors standings reported in a GPA report
+const (
+	HonorsNone      = ""
+	HonorsHonor     = "honor"      // Onur
+	HonorsHighHonor = "high_honor" // Yüksek Onur
+)
+
+// PassFailSummary totals the pass/fail graded courses kept out of the GPA
+type PassFailSummary struct {
+	EarnedCredits float64 `json:"earnedCredits"`
+	PassedCount   int     `json:"passedCount"`
+	FailedCount   int     `json:"failedCount"`
+}
+
+// GPAReport is the full GPA and credit summary of a list of courses
+type GPAReport struct {
+	Cumulative GPASummary        `json:"cumulative"`
+	Semesters  []SemesterSummary `json:"semesters"`
+	PassFail   PassFailSummary   `json:"passFail"`
+	Honors     string            `json:"honors,omitempty"`
+	// Withdrawn lists the courses kept out of the GPA and earned credits by a withdrawal
+	Withdrawn []Course `json:"withdrawn"`
+}
+
+// BuildGPAReport computes the cumulative and per-semester GPA of courses and
+// the honors standing the cumulative GPA earns, by the default institution's
+// grade scale and honors thresholds
+func BuildGPAReport(courses []Course) GPAReport {
+	return buildGPAReport(courses, defaultProfile())
+}
+
+// buildGPAReport computes the GPA report of courses by the grade scale and
+// honors thresholds of profile
+func buildGPAReport(courses []Course, profile *InstitutionProfile) GPAReport {
+	scale := gradeScales[profile.GradeScale]
+	report := GPAReport{}
+	report.Cumulative = calculateGPASummary(courses, scale)
+	report.Semesters = calculateSemesterGPAs(courses, scale)
+	report.PassFail = CalculatePassFailSummary(courses)
+	report.Withdrawn = WithdrawnCourses(courses)
+	report.Honors = honorsFor(report.Cumulative.GPA, profile)
+	return report
+}
+
+// honorsFor returns the honors standing a cumulative GPA earns at the institution
+func honorsFor(gpa float64, profile *InstitutionProfile) string {
+	switch {
+	case gpa >= profile.HighHonorsGPAThreshold:
+		return HonorsHighHonor
+	case gpa >= profile.HonorsGPAThreshold:
+		return HonorsHonor
+	}
+	return HonorsNone
+}
+
+// ComputeGPARequest represents the request body
+type ComputeGPARequest struct {
+	Courses []Course `json:"courses"`
+	// Places and Rounding control how GPAs are rounded, see GPARequest
+	Places   int    `json:"places,omitempty"`
+	Rounding string `json:"rounding,omitempty"`
+	// Institution selects the grade scale, rounding and honors thresholds;
+	// defaults to defaultInstitution
+	Institution string `json:"institution,omitempty"`
+}
+
+// ComputeGPA is a stateless GPA calculator for callers that don't store a transcript.
+//
+//encore:api public method=POST path=/compute-gpa
+func ComputeGPA(ctx context.Context, req *ComputeGPARequest) (*GPAReport, error) {
+	if len(req.Courses) == 0 {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "courses cannot be empty",
+		}
+	}
+	if err := validateCourseCount(req.Courses); err != nil {
+		return nil, err
+	}
+	profile, err := lookupInstitution(req.Institution)
+	if err != nil {
+		return nil, err
+	}
+	rounding, err := gpaRoundingFor(profile, req.Places, req.Rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, course := range req.Courses {
+		if err := validateCourse(course); err != nil {
+			return nil, &errs.Error{
+				Code:    errs.InvalidArgument,
+				Message: fmt.Sprintf("course %d: %v", i, err),
+			}
+		}
+	}
+
+	report := buildGPAReport(req.Courses, profile)
+	report.Cumulative = rounding.RoundSummary(report.Cumulative)
+	for i := range report.Semesters {
+		report.Semesters[i].GPASummary = rounding.RoundSummary(report.Semesters[i].GPASummary)
+	}
+	return &report, nil
+}
@@ -0,0 +1,96 @@
+package transcript
+
+import (
+	"math/big"
+	"strconv"
+
+	"encore.dev/beta/errs"
+)
+
+// Rounding modes for displayed GPA values
+const (
+	// RoundHalfUp rounds ties away from zero on the decimal value, so 2.995
+	// becomes 3.00. This is how ITU rounds the GPA printed on transcripts.
+	RoundHalfUp = "half_up"
+	// RoundHalfEven rounds ties to the nearest even digit (banker's rounding)
+	RoundHalfEven = "half_even"
+	// RoundTruncate drops the digits beyond the requested places
+	RoundTruncate = "truncate"
+)
+
+// maxGPAPlaces caps the decimal places a GPA can be rounded to
+const maxGPAPlaces = 6
+
+// GPARounding controls how GPA values are rounded for display
+type GPARounding struct {
+	Places int
+	Mode   string
+}
+
+// gpaRoundingFor returns the rounding requested by an endpoint's places and
+// mode parameters, falling back to the institution's convention for the ones
+// left empty
+func gpaRoundingFor(profile *InstitutionProfile, places int, mode string) (GPARounding, error) {
+	rounding := profile.GPARounding
+	if places != 0 {
+		rounding.Places = places
+	}
+	if mode != "" {
+		rounding.Mode = mode
+	}
+
+	if rounding.Places < 0 || rounding.Places > maxGPAPlaces {
+		return rounding, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "places must be between 0 and " + strconv.Itoa(maxGPAPlaces),
+		}
+	}
+	switch rounding.Mode {
+	case RoundHalfUp, RoundHalfEven, RoundTruncate:
+	default:
+		return rounding, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "unknown rounding mode: " + rounding.Mode,
+		}
+	}
+	return rounding, nil
+}
+
+// Round rounds value to the configured places. It works on the shortest decimal
+// representation of value rather than its binary one, so 1.005 rounds half up
+// to 1.01 instead of the 1.00 that math.Round(1.005*100)/100 gives.
+func (r GPARounding) Round(value float64) float64 {
+	decimal, ok := new(big.Rat).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+	if !ok {
+		return value
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(r.Places)), nil)
+	decimal.Mul(decimal, new(big.Rat).SetInt(scale))
+
+	// Split the scaled value into its integer part and remainder; both carry
+	// the sign of value since Int.QuoRem truncates toward zero
+	quotient, remainder := new(big.Int).QuoRem(decimal.Num(), decimal.Denom(), new(big.Int))
+	twiceRemainder := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	tie := twiceRemainder.Cmp(decimal.Denom())
+
+	roundAway := false
+	switch r.Mode {
+	case RoundHalfUp:
+		roundAway = tie >= 0
+	case RoundHalfEven:
+		roundAway = tie > 0 || (tie == 0 && quotient.Bit(0) == 1)
+	}
+	if roundAway {
+		quotient.Add(quotient, big.NewInt(int64(remainder.Sign())))
+	}
+
+	rounded, _ := new(big.Rat).SetFrac(quotient, scale).Float64()
+	return rounded
+}
+
+// RoundSummary returns a copy of summary with its GPA rounded
+func (r GPARounding) RoundSummary(summary GPASummary) GPASummary {
+	summary.GPA = r.Round(summary.GPA)
+	return summary
+}
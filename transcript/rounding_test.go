@@ -0,0 +1,81 @@
+package transcript
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"encore.dev/beta/errs"
+)
+
+func TestGPARoundingRound(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		r     GPARounding
+		want  float64
+	}{
+		{"half up at a binary tie", 2.995, GPARounding{Places: 2, Mode: RoundHalfUp}, 3.00},
+		{"half up below the tie", 2.994, GPARounding{Places: 2, Mode: RoundHalfUp}, 2.99},
+		{"half up 1.005", 1.005, GPARounding{Places: 2, Mode: RoundHalfUp}, 1.01},
+		{"half up to 3 places", 3.4565, GPARounding{Places: 3, Mode: RoundHalfUp}, 3.457},
+		{"half up to whole points", 2.5, GPARounding{Places: 0, Mode: RoundHalfUp}, 3},
+		{"half even rounds to the even digit", 2.985, GPARounding{Places: 2, Mode: RoundHalfEven}, 2.98},
+		{"half even away from the odd digit", 2.995, GPARounding{Places: 2, Mode: RoundHalfEven}, 3.00},
+		{"half even above the tie", 2.9851, GPARounding{Places: 2, Mode: RoundHalfEven}, 2.99},
+		{"truncate", 2.999, GPARounding{Places: 2, Mode: RoundTruncate}, 2.99},
+		{"already rounded", 3.25, GPARounding{Places: 2, Mode: RoundHalfUp}, 3.25},
+		{"zero", 0, GPARounding{Places: 2, Mode: RoundHalfUp}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Round(tt.value); got != tt.want {
+				t.Errorf("Round(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGPARoundingDivergesFromNaiveRounding(t *testing.T) {
+	// 1.005 is stored as 1.00499999..., so scaling and rounding the float gives 1.00
+	gpa := 1.005
+	naive := math.Round(gpa*100) / 100
+	itu := GPARounding{Places: 2, Mode: RoundHalfUp}.Round(gpa)
+	if naive != 1.00 || itu != 1.01 {
+		t.Errorf("naive = %v, ITU = %v; want 1.00 and 1.01", naive, itu)
+	}
+}
+
+func TestGPARoundingFor(t *testing.T) {
+	profile := defaultProfile()
+
+	tests := []struct {
+		name    string
+		places  int
+		mode    string
+		want    GPARounding
+		wantErr bool
+	}{
+		{"institution default", 0, "", profile.GPARounding, false},
+		{"places override", 3, "", GPARounding{Places: 3, Mode: profile.GPARounding.Mode}, false},
+		{"mode override", 0, RoundTruncate, GPARounding{Places: profile.GPARounding.Places, Mode: RoundTruncate}, false},
+		{"too many places", maxGPAPlaces + 1, "", GPARounding{}, true},
+		{"negative places", -1, "", GPARounding{}, true},
+		{"unknown mode", 0, "ceiling", GPARounding{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := gpaRoundingFor(profile, tt.places, tt.mode)
+			if tt.wantErr {
+				var apiErr *errs.Error
+				if !errors.As(err, &apiErr) || apiErr.Code != errs.InvalidArgument {
+					t.Errorf("gpaRoundingFor() error = %v, want InvalidArgument", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("gpaRoundingFor() = %+v, %v; want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package transcript
+
+import "encore.app/semester"
+
+// semestersOf returns the distinct semesters of the given courses in chronological order
+func semestersOf(courses []Course) []string {
+	seen := make(map[string]bool)
+	var semesters []string
+	for _, course := range courses {
+		if !seen[course.Semester] {
+			seen[course.Semester] = true
+			semesters = append(semesters, course.Semester)
+		}
+	}
+	semester.Sort(semesters)
+	return semesters
+}
+
+// latestSemester returns the most recent recognized semester of the given courses,
+// falling back to the last label when none can be parsed
+func latestSemester(courses []Course) (string, bool) {
+	semesters := semestersOf(courses)
+	if len(semesters) == 0 {
+		return "", false
+	}
+
+	for i := len(semesters) - 1; i >= 0; i-- {
+		if _, ok := semester.Parse(semesters[i]); ok {
+			return semesters[i], true
+		}
+	}
+	return semesters[len(semesters)-1], true
+}
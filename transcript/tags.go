@@ -0,0 +1,147 @@
+package transcript
+
+import (
+	"context"
+	"strings"
+
+	"encore.dev/beta/errs"
+)
+
+// maxCourseTagLength caps the length of a course tag
+const maxCourseTagLength = 50
+
+// CourseTag is a student-defined tag on a course, such as "favorites" or "to retake".
+// Tags are keyed by semester and course code, so they survive re-parsing.
+type CourseTag struct {
+	Semester string `json:"semester"`
+	Code     string `json:"code"`
+	Tag      string `json:"tag"`
+}
+
+// CourseTagsResponse lists a user's course tags
+type CourseTagsResponse struct {
+	Tags []CourseTag `json:"tags"`
+}
+
+//encore:api public method=POST path=/transcript/:userID/course/tags
+func TagCourse(ctx context.Context, userID string, req *CourseTag) (*CourseTagsResponse, error) {
+	tag, err := validateCourseTag(req)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript, err := loadTranscript(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasCourse(transcript.Courses, tag) {
+		return nil, &errs.Error{
+			Code:    errs.NotFound,
+			Message: "course not found in transcript",
+		}
+	}
+
+	if err := InsertCourseTag(ctx, userID, tag); err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to store course tag",
+		}
+	}
+
+	return ListCourseTags(ctx, userID)
+}
+
+//encore:api public method=DELETE path=/transcript/:userID/course/tags
+func UntagCourse(ctx context.Context, userID string, req *CourseTag) (*CourseTagsResponse, error) {
+	tag, err := validateCourseTag(req)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := DeleteCourseTag(ctx, userID, tag)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to remove course tag",
+		}
+	}
+	if !removed {
+		return nil, &errs.Error{
+			Code:    errs.NotFound,
+			Message: "course tag not found",
+		}
+	}
+
+	return ListCourseTags(ctx, userID)
+}
+
+//encore:api public method=GET path=/transcript/:userID/tags
+func ListCourseTags(ctx context.Context, userID string) (*CourseTagsResponse, error) {
+	if userID == "" {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "user_id is required",
+		}
+	}
+
+	tags, err := GetCourseTags(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.Internal,
+			Message: "failed to retrieve course tags",
+		}
+	}
+
+	return &CourseTagsResponse{Tags: tags}, nil
+}
+
+// validateCourseTag checks a tag request and returns it with the tag trimmed
+func validateCourseTag(req *CourseTag) (CourseTag, error) {
+	tag := CourseTag{
+		Semester: req.Semester,
+		Code:     req.Code,
+		Tag:      strings.TrimSpace(req.Tag),
+	}
+	if tag.Semester == "" || tag.Code == "" || tag.Tag == "" {
+		return tag, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "semester, code and tag are required",
+		}
+	}
+	if len(tag.Tag) > maxCourseTagLength {
+		return tag, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "tag is too long",
+		}
+	}
+	return tag, nil
+}
+
+// hasCourse reports whether courses contain the course a tag refers to
+func hasCourse(courses []Course, tag CourseTag) bool {
+	key := courseKey(Course{Semester: tag.Semester, Code: tag.Code})
+	for _, course := range courses {
+		if courseKey(course) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// filterByTag returns the courses carrying the given tag
+func filterByTag(courses []Course, tags []CourseTag, tag string) []Course {
+	tagged := make(map[string]bool)
+	for _, t := range tags {
+		if t.Tag == tag {
+			tagged[courseKey(Course{Semester: t.Semester, Code: t.Code})] = true
+		}
+	}
+
+	filtered := []Course{}
+	for _, course := range courses {
+		if tagged[courseKey(course)] {
+			filtered = append(filtered, course)
+		}
+	}
+	return filtered
+}
@@ -5,60 +5,195 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"encore.app/grades"
+	"encore.app/semester"
+	"encore.dev/beta/errs"
+	"encore.dev/rlog"
 	"github.com/ledongthuc/pdf"
 )
 
 // TranscriptCourse represents a single course in the transcript
 type TranscriptCourse struct {
-	Semester  string `json:"semester"`
-	Code      string `json:"code"`
-	Name      string `json:"name"`
-	Credits   string `json:"credits"`
-	Grade     string `json:"grade"`
-	LessonID  string `json:"lesson_id,omitempty"`
+	Semester string `json:"semester"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Credits  string `json:"credits"`
+	Grade    string `json:"grade"`
+	LessonID string `json:"lesson_id,omitempty"`
+	// ECTS is the AKTS column, when the parser could read it
+	ECTS string `json:"ects,omitempty"`
+	// CreditsFromECTS is set when the UK column was blank and AKTS was used as credits
+	CreditsFromECTS bool `json:"credits_from_ects,omitempty"`
+	// Points is the grade points column (Puan), when the parser could read it
+	Points string `json:"points,omitempty"`
+	// InProgress is set when the grade is one of the in-progress markers (no grade yet)
+	InProgress bool `json:"in_progress,omitempty"`
+	// Exchange marks a course taken abroad (e.g. Erasmus); it is kept out of the GPA
+	Exchange bool `json:"exchange,omitempty"`
+	// Language is the instruction language of an exchange course, when listed
+	Language string `json:"language,omitempty"`
+	// ParseSource names the parser branch that produced the course; only set in debug mode
+	ParseSource string `json:"parse_source,omitempty"`
+	// RawText is the slice of transcript text the course was parsed from; it is
+	// only reported through the debug course traces
+	RawText string `json:"-"`
 }
 
 // ParseTranscriptRequest represents the request body
 type ParseTranscriptRequest struct {
 	// PDF file content as base64 encoded string
 	PDFBase64 string `json:"pdf_base64"`
+	// PDFsBase64 holds the parts of a transcript split across several PDFs, in
+	// order; when set, PDFBase64 is ignored
+	PDFsBase64 []string `json:"pdfs_base64,omitempty"`
+	// Set to "semester" to also return the courses grouped by semester
+	Group string `query:"group"`
+	// Debug tags each course with the parser branch that produced it,
+	// returns a structured trace of every parsed course and includes the
+	// parser's free-form log in the response's Debug field
+	Debug bool `query:"debug"`
+	// ExpectedCourseCount is the number of courses the student expects; a
+	// parse result far from it is flagged for manual review
+	ExpectedCourseCount int `json:"expectedCourseCount,omitempty"`
+	// Institution selects the transcript layout; defaults to defaultInstitution
+	Institution string `query:"institution"`
+	// VerifyStudentNumber is compared with the student number printed on the
+	// transcript; the response reports whether they match
+	VerifyStudentNumber string `json:"verifyStudentNumber,omitempty"`
+	// WarningsOnly reduces the response to the diagnostics, error and review
+	// warning. Unlike the stored parse log, it also explains parses that fail.
+	WarningsOnly bool `query:"warningsOnly"`
+}
+
+// SemesterCourses groups the parsed courses of a single semester
+type SemesterCourses struct {
+	Semester string             `json:"semester"`
+	Courses  []TranscriptCourse `json:"courses"`
 }
 
 // ParseTranscriptResponse represents the response
 type ParseTranscriptResponse struct {
-	Courses []TranscriptCourse `json:"courses"`
-	Error   string             `json:"error,omitempty"`
-	Debug   string             `json:"debug,omitempty"`
+	Courses     []TranscriptCourse `json:"courses"`
+	Semesters   []SemesterCourses  `json:"semesters,omitempty"`
+	Program     string             `json:"program,omitempty"`
+	Diagnostics *ParseDiagnostics  `json:"diagnostics,omitempty"`
+	// CourseTraces shows what each course was parsed from; only set in debug mode
+	CourseTraces []CourseTrace `json:"courseTraces,omitempty"`
+	// OfficialTotals holds the TUK/TAKTS totals printed on the transcript, when present
+	OfficialTotals *OfficialTotals `json:"officialTotals,omitempty"`
+	// StudentNumberMatch reports whether the transcript's student number equals
+	// VerifyStudentNumber; only set when a number to verify was given
+	StudentNumberMatch *bool `json:"studentNumberMatch,omitempty"`
+	// StudentNumber is the number printed on the transcript. It is personal data,
+	// so it is never serialized and only kept by callers that are asked to.
+	StudentNumber string `json:"-"`
+	// ReviewWarning recommends manual review when the parse result looks incomplete
+	ReviewWarning string `json:"reviewWarning,omitempty"`
+	Error         string `json:"error,omitempty"`
+	// Debug is the parser's free-form log; only set when the request asks for it
+	Debug      string `json:"debug,omitempty"`
+	APIVersion string `header:"X-API-Version"`
 }
 
 //encore:api public method=POST path=/parse-transcript
 func ParseTranscript(ctx context.Context, req *ParseTranscriptRequest) (*ParseTranscriptResponse, error) {
-	var debugInfo strings.Builder
-	
-	// Decode base64 PDF content
-	pdfBytes, err := base64.StdEncoding.DecodeString(req.PDFBase64)
-	if err != nil {
-		return &ParseTranscriptResponse{
-			Error: fmt.Sprintf("Failed to decode base64 PDF: %v", err),
-		}, nil
+	var resp *ParseTranscriptResponse
+	var pdfBytes []byte
+	var err error
+	if len(req.PDFsBase64) == 0 {
+		pdfBytes, resp, err = decodeUploadedPDF(req.PDFBase64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp == nil {
+		resp, err = parseTranscriptPDF(ctx, req, pdfBytes)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	debugInfo.WriteString(fmt.Sprintf("PDF decoded successfully, size: %d bytes\n", len(pdfBytes)))
+	if req.WarningsOnly {
+		resp = warningsOnly(resp)
+	}
+	return resp, nil
+}
+
+// warningsOnly reduces a parse response to what WarningsOnly requests get.
+// Diagnostics is always set, so its absence never hides a failed parse.
+func warningsOnly(resp *ParseTranscriptResponse) *ParseTranscriptResponse {
+	diagnostics := resp.Diagnostics
+	if diagnostics == nil {
+		diagnostics = &ParseDiagnostics{}
+	}
+	return &ParseTranscriptResponse{
+		Diagnostics:   diagnostics,
+		ReviewWarning: resp.ReviewWarning,
+		Error:         resp.Error,
+		Debug:         resp.Debug,
+	}
+}
+
+// parseTranscriptPDF parses req with the single PDF already decoded to pdfBytes,
+// or the PDFs of req.PDFsBase64 when there are several. Callers holding the
+// decoded PDF use it so the upload isn't decoded again.
+func parseTranscriptPDF(ctx context.Context, req *ParseTranscriptRequest, pdfBytes []byte) (*ParseTranscriptResponse, error) {
+	var debugInfo strings.Builder
 
-	// Extract text from PDF
-	text, err := extractTextFromPDF(pdfBytes)
+	profile, err := lookupInstitution(req.Institution)
 	if err != nil {
-		return &ParseTranscriptResponse{
-			Error: fmt.Sprintf("Failed to extract text from PDF: %v", err),
-		}, nil
+		return nil, err
+	}
+	if err := validatePDFUploads(req.PDFsBase64); err != nil {
+		return nil, err
+	}
+	
+	// Extract text from the PDFs, bounded by the parse timeout so a pathological
+	// document can't hold the request indefinitely
+	extractCtx, cancel := context.WithTimeout(ctx, pdfParseTimeout)
+	defer cancel()
+
+	diagnostics := &ParseDiagnostics{}
+	var text string
+	if len(req.PDFsBase64) > 0 {
+		text, err = extractFilesText(extractCtx, req.PDFsBase64, diagnostics, &debugInfo)
+		if err != nil {
+			return nil, err
+		}
+		if text == "" {
+			return &ParseTranscriptResponse{
+				Error:       "None of the uploaded PDFs could be read",
+				Diagnostics: diagnostics,
+			}, nil
+		}
+	} else {
+		extracted, failure, err := extractPDFText(extractCtx, pdfBytes, &debugInfo)
+		if err != nil {
+			return nil, err
+		}
+		if failure != nil {
+			return failure, nil
+		}
+		text = extracted.text
+		for _, page := range extracted.failedPages {
+			diagnostics.warn(WarningPageExtractFailed, "", "", fmt.Sprintf("page %d could not be extracted; its courses may be missing", page))
+		}
 	}
 
-	debugInfo.WriteString(fmt.Sprintf("Text extracted successfully, length: %d characters\n", len(text)))
+	// Repair broken encodings before parsing so the patterns see proper Turkish characters
+	if repaired, changed := repairText(text); changed {
+		text = repaired
+		diagnostics.warn(WarningTextRepaired, "", "", "extracted text had invalid UTF-8 or mojibake and was repaired")
+	}
 	
 	// Debug: Add first 500 characters of extracted text to debug info
 	previewLength := 500
@@ -70,81 +205,527 @@ func ParseTranscript(ctx context.Context, req *ParseTranscriptRequest) (*ParseTr
 	// Debug: Check if text was extracted
 	if len(text) == 0 {
 		return &ParseTranscriptResponse{
-			Error: "No text extracted from PDF - PDF might be empty or unreadable",
+			Error:       "No text extracted from PDF - PDF might be empty or unreadable",
+			Diagnostics: diagnostics,
 		}, nil
 	}
 
+	// Some text but too little for a transcript, e.g. only a cover page
+	if chars := utf8.RuneCountInString(text); chars < minTranscriptTextLength {
+		return nil, &errs.Error{
+			Code:    errs.FailedPrecondition,
+			Message: fmt.Sprintf("extracted text is too short to be a transcript: %d characters, need at least %d", chars, minTranscriptTextLength),
+		}
+	}
+
 	// Parse the transcript text
-	courses, parseDebug, err := parseTranscriptText(text)
+	courses, parseDebug, err := parseTranscriptText(text, profile)
 	if err != nil {
 		debugInfo.WriteString(fmt.Sprintf("Parse error: %v\n", err))
 		debugInfo.WriteString(parseDebug)
 		return &ParseTranscriptResponse{
-			Error: fmt.Sprintf("Failed to parse transcript: %v", err),
-			Debug: debugInfo.String(),
+			Error:       fmt.Sprintf("Failed to parse transcript: %v", err),
+			Diagnostics: diagnostics,
+			Debug:       debugText(req.Debug, &debugInfo),
 		}, nil
 	}
 	
 	// Add parse debug info to main debug info
 	debugInfo.WriteString(parseDebug)
 
+	if parseExchangeSections {
+		exchange := parseExchangeCourses(text, profile)
+		debugInfo.WriteString(fmt.Sprintf("Found %d exchange courses\n", len(exchange)))
+		courses = append(courses, exchange...)
+	}
+
+	courses = dedupeCourses(courses, diagnostics)
+
 	// Debug: Check if courses were found
 	if len(courses) == 0 {
+		report := diagnostics.diagnoseNoCourses(text, profile)
 		return &ParseTranscriptResponse{
-			Error: "No courses found in transcript. Check debug logs for details.",
-			Debug: debugInfo.String(),
+			Error:       fmt.Sprintf("No courses found in transcript: %s", report.Reason),
+			Diagnostics: diagnostics,
+			Debug:       debugText(req.Debug, &debugInfo),
 		}, nil
 	}
 
-	return &ParseTranscriptResponse{
-		Courses: courses,
-		Debug:   debugInfo.String(),
-	}, nil
+	var traces []CourseTrace
+	if req.Debug {
+		traces = traceCourses(courses)
+	}
+	for i := range courses {
+		courses[i].InProgress = grades.IsInProgress(courses[i].Grade)
+		if !req.Debug {
+			courses[i].ParseSource = ""
+		}
+	}
+
+	resp := &ParseTranscriptResponse{
+		Courses:        courses,
+		Program:        extractProgram(text, profile),
+		StudentNumber:  extractStudentNumber(text, profile),
+		Diagnostics:    diagnostics,
+		CourseTraces:   traces,
+		OfficialTotals: parseOfficialTotals(text),
+		Debug:          debugText(req.Debug, &debugInfo),
+	}
+	if req.VerifyStudentNumber != "" {
+		match := resp.StudentNumber != "" && resp.StudentNumber == strings.TrimSpace(req.VerifyStudentNumber)
+		resp.StudentNumberMatch = &match
+	}
+	if resp.OfficialTotals != nil {
+		diagnostics.checkOfficialTotals(resp.OfficialTotals, courses)
+	}
+
+	if req.ExpectedCourseCount > 0 && courseCountMismatch(len(courses), req.ExpectedCourseCount) {
+		resp.ReviewWarning = fmt.Sprintf("Parsed %d courses but %d were expected; please review the transcript manually", len(courses), req.ExpectedCourseCount)
+		diagnostics.warn(WarningCourseCountMismatch, "", "", resp.ReviewWarning)
+	}
+
+	switch req.Group {
+	case "":
+	case "semester":
+		resp.Semesters = groupBySemester(courses)
+	default:
+		return &ParseTranscriptResponse{
+			Error: fmt.Sprintf("Unsupported group value: %q", req.Group),
+		}, nil
+	}
+
+	return resp, nil
+}
+
+// decodeUploadedPDF decodes an uploaded base64 PDF and checks that it is one.
+// Invalid base64 is reported as a failed parse response, like an unreadable PDF.
+func decodeUploadedPDF(pdfBase64 string) ([]byte, *ParseTranscriptResponse, error) {
+	pdfBytes, err := decodePDFBase64(pdfBase64)
+	if errors.Is(err, errPDFTooLarge) {
+		return nil, nil, pdfDecodeError(err)
+	}
+	if err != nil {
+		return nil, &ParseTranscriptResponse{
+			Error: fmt.Sprintf("Failed to decode base64 PDF: %v", err),
+		}, nil
+	}
+	if err := checkPDFContentType(pdfBytes); err != nil {
+		return nil, nil, err
+	}
+	return pdfBytes, nil, nil
+}
+
+// extractPDFText extracts the text of one decoded PDF. A PDF that can't be read
+// yields a response carrying the error for the caller to return; err is
+// reserved for failures that should fail the request outright.
+func extractPDFText(ctx context.Context, pdfBytes []byte, debugInfo *strings.Builder) (pdfText, *ParseTranscriptResponse, error) {
+	debugInfo.WriteString(fmt.Sprintf("PDF decoded successfully, size: %d bytes\n", len(pdfBytes)))
+
+	extracted, err := extractTextWithRetry(ctx, pdfBytes)
+	if ctxErr := extractionContextError(err); ctxErr != nil {
+		return pdfText{}, nil, ctxErr
+	}
+	if err != nil {
+		return pdfText{}, &ParseTranscriptResponse{
+			Error: fmt.Sprintf("Failed to extract text from PDF: %v", err),
+		}, nil
+	}
+
+	debugInfo.WriteString(fmt.Sprintf("Text extracted successfully, length: %d characters\n", len(extracted.text)))
+	return extracted, nil, nil
+}
+
+// extractionContextError maps an extraction stopped by its context to an API
+// error: DeadlineExceeded when the parse timeout passed and Canceled when the
+// caller went away. It returns nil for any other error.
+func extractionContextError(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return &errs.Error{
+			Code:    errs.DeadlineExceeded,
+			Message: "PDF text extraction did not finish in time",
+		}
+	case errors.Is(err, context.Canceled):
+		return &errs.Error{
+			Code:    errs.Canceled,
+			Message: "PDF text extraction was canceled",
+		}
+	}
+	return nil
+}
+
+// extractFilesText extracts the text of several PDFs making up one transcript
+// and concatenates it in upload order. A PDF that can't be read is reported in
+// diagnostics and skipped; only running out of time or a canceled request fails
+// the whole request. Courses repeated across files (such as an overlapping
+// page) are collapsed later by dedupeCourses.
+func extractFilesText(ctx context.Context, pdfsBase64 []string, diagnostics *ParseDiagnostics, debugInfo *strings.Builder) (string, error) {
+	var texts []string
+	for i, pdfBase64 := range pdfsBase64 {
+		report := FileReport{Index: i}
+		var extracted pdfText
+		pdfBytes, failure, err := decodeUploadedPDF(pdfBase64)
+		if err == nil && failure == nil {
+			extracted, failure, err = extractPDFText(ctx, pdfBytes, debugInfo)
+		}
+		switch {
+		case errs.Code(err) == errs.DeadlineExceeded || errs.Code(err) == errs.Canceled:
+			return "", err
+		case err != nil:
+			report.Error = err.Error()
+		case failure != nil:
+			report.Error = failure.Error
+		}
+		if report.Error != "" {
+			diagnostics.warn(WarningFileFailed, "", "", fmt.Sprintf("file %d could not be read: %s", i+1, report.Error))
+			diagnostics.Files = append(diagnostics.Files, report)
+			continue
+		}
+
+		for _, page := range extracted.failedPages {
+			diagnostics.warn(WarningPageExtractFailed, "", "", fmt.Sprintf("file %d page %d could not be extracted; its courses may be missing", i+1, page))
+		}
+		report.Pages = extracted.pages
+		report.Characters = utf8.RuneCountInString(extracted.text)
+		diagnostics.Files = append(diagnostics.Files, report)
+		texts = append(texts, extracted.text)
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// validatePDFUploads rejects a multi-file upload with more than maxPDFFiles
+// files or whose PDFs together would decode to more than maxTotalPDFSizeBytes.
+// The size is computed from the base64 lengths, before anything is decoded.
+func validatePDFUploads(pdfsBase64 []string) error {
+	if len(pdfsBase64) > maxPDFFiles {
+		return &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: fmt.Sprintf("too many PDFs: %d, at most %d can be uploaded together", len(pdfsBase64), maxPDFFiles),
+		}
+	}
+	total := 0
+	for _, pdfBase64 := range pdfsBase64 {
+		total += base64.StdEncoding.DecodedLen(len(pdfBase64))
+	}
+	if total > maxTotalPDFSizeBytes {
+		return &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: fmt.Sprintf("PDFs exceed the maximum combined size of %d MB", maxTotalPDFSizeBytes>>20),
+		}
+	}
+	return nil
+}
+
+// courseCountMismatch reports whether the parsed course count differs from the
+// expected count by more than courseCountTolerance
+func courseCountMismatch(parsed, expected int) bool {
+	diff := float64(parsed - expected)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff > courseCountTolerance*float64(expected)
+}
+
+// extractProgram returns the academic program named in the transcript text, if any
+func extractProgram(text string, profile *InstitutionProfile) string {
+	match := profile.ProgramPattern.FindStringSubmatch(text)
+	if match == nil {
+		return ""
+	}
+	return strings.TrimSpace(match[1])
+}
+
+// extractStudentNumber returns the student number printed in the transcript text, if any
+func extractStudentNumber(text string, profile *InstitutionProfile) string {
+	match := profile.StudentNumberPattern.FindStringSubmatch(text)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// groupBySemester groups parsed courses by semester in chronological order
+func groupBySemester(courses []TranscriptCourse) []SemesterCourses {
+	bySemester := make(map[string][]TranscriptCourse)
+	var semesters []string
+	for _, course := range courses {
+		if _, ok := bySemester[course.Semester]; !ok {
+			semesters = append(semesters, course.Semester)
+		}
+		bySemester[course.Semester] = append(bySemester[course.Semester], course)
+	}
+	semester.Sort(semesters)
+
+	groups := make([]SemesterCourses, 0, len(semesters))
+	for _, semester := range semesters {
+		groups = append(groups, SemesterCourses{
+			Semester: semester,
+			Courses:  bySemester[semester],
+		})
+	}
+	return groups
+}
+
+// dedupeCourses collapses parse artifacts: rows repeated verbatim under the same
+// semester and code. Rows sharing a semester and code but differing in grade or
+// credits are genuine in-term repeats and are kept. Both decisions are recorded
+// in diagnostics.
+func dedupeCourses(courses []TranscriptCourse, diagnostics *ParseDiagnostics) []TranscriptCourse {
+	seen := make(map[string][]TranscriptCourse)
+	var deduped []TranscriptCourse
+	for _, course := range courses {
+		key := course.Semester + "|" + course.Code
+
+		identical := false
+		for _, prior := range seen[key] {
+			if sameCourseRow(prior, course) {
+				identical = true
+				break
+			}
+		}
+
+		switch {
+		case identical:
+			diagnostics.warn(WarningDuplicateRemoved, course.Semester, course.Code,
+				"identical row parsed twice; duplicate removed")
+			continue
+		case len(seen[key]) > 0:
+			diagnostics.warn(WarningInTermRepeat, course.Semester, course.Code,
+				"course appears more than once in the semester with different grade or credits; all rows kept")
+		}
+
+		seen[key] = append(seen[key], course)
+		deduped = append(deduped, course)
+	}
+	return deduped
+}
+
+// sameCourseRow reports whether two parsed rows carry the same course data
+func sameCourseRow(a, b TranscriptCourse) bool {
+	return a.Name == b.Name && a.Credits == b.Credits && a.Grade == b.Grade
+}
+
+// errTooManyFailedPages is returned when so many pages fail to extract that
+// the remaining text can't be trusted to hold the whole transcript
+var errTooManyFailedPages = errors.New("too many PDF pages failed to extract")
+
+// extractTextFromPDF extracts text from PDF bytes, giving up as soon as ctx is done.
+// It also returns the numbers of pages whose text couldn't be read.
+func extractTextFromPDF(ctx context.Context, pdfBytes []byte) (pdfText, error) {
+	return readInBackground(ctx, func() (pdfText, error) {
+		return readPDFText(ctx, pdfBytes)
+	})
+}
+
+// readInBackground runs read in a goroutine and returns its result, or ctx's
+// error as soon as ctx is done. The PDF library doesn't take a context; read
+// stops at the next page boundary once ctx is cancelled. The library panics on
+// malformed object trees, and Encore only recovers panics on the handler
+// goroutine, so a panic in read is returned as an error.
+func readInBackground(ctx context.Context, read func() (pdfText, error)) (pdfText, error) {
+	type result struct {
+		extracted pdfText
+		err       error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{err: fmt.Errorf("pdf: %v", r)}
+			}
+		}()
+		extracted, err := read()
+		done <- result{extracted, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return pdfText{}, ctx.Err()
+	case r := <-done:
+		return r.extracted, r.err
+	}
+}
+
+// pdfText is the text read from a PDF along with its page count and the
+// numbers of the pages that couldn't be read
+type pdfText struct {
+	text        string
+	pages       int
+	failedPages []int
 }
 
-// extractTextFromPDF extracts text from PDF bytes
-func extractTextFromPDF(pdfBytes []byte) (string, error) {
+// readPDFText reads the plain text of every page of a PDF. Pages that can't be
+// read are skipped and reported by number, unless more than maxFailedPageRatio
+// of the pages fail.
+func readPDFText(ctx context.Context, pdfBytes []byte) (pdfText, error) {
 	// Create a reader for the PDF bytes
 	reader := bytes.NewReader(pdfBytes)
 	
 	// Parse the PDF
 	pdfReader, err := pdf.NewReader(reader, int64(len(pdfBytes)))
 	if err != nil {
-		return "", fmt.Errorf("failed to create PDF reader: %v", err)
+		return pdfText{}, fmt.Errorf("failed to create PDF reader: %w", err)
 	}
 
-	// Extract text from all pages
-	var text bytes.Buffer
-	for i := 1; i <= pdfReader.NumPage(); i++ {
+	return readPages(ctx, pdfReader.NumPage(), func(i int) (string, error) {
 		page := pdfReader.Page(i)
 		if page.V.IsNull() {
-			continue
+			return "", nil
 		}
-		
-		pageText, err := page.GetPlainText(nil)
-		if err != nil {
-			continue // Skip pages that can't be read
+		return page.GetPlainText(nil)
+	})
+}
+
+// readPages joins the text of numPages pages read with pageText, skipping and
+// reporting the pages that fail
+func readPages(ctx context.Context, numPages int, pageText func(page int) (string, error)) (pdfText, error) {
+	var text bytes.Buffer
+	var failedPages []int
+	for i := 1; i <= numPages; i++ {
+		if err := ctx.Err(); err != nil {
+			return pdfText{}, err
 		}
-		
 
+		content, err := pageText(i)
+		if err != nil {
+			failedPages = append(failedPages, i)
+			continue
+		}
+		if content == "" {
+			continue
+		}
 		
-		text.WriteString(pageText)
+		text.WriteString(content)
 		text.WriteString("\n")
 	}
 
-	return text.String(), nil
+	if float64(len(failedPages)) > maxFailedPageRatio*float64(numPages) {
+		return pdfText{pages: numPages, failedPages: failedPages}, fmt.Errorf("%w: %d of %d pages", errTooManyFailedPages, len(failedPages), numPages)
+	}
+
+	return pdfText{text: text.String(), pages: numPages, failedPages: failedPages}, nil
+}
+
+// extractTextWithRetry runs extractTextFromPDF, retrying transient failures
+// with a short linear backoff. Fatal errors (encrypted or corrupt documents)
+// are returned immediately.
+func extractTextWithRetry(ctx context.Context, pdfBytes []byte) (pdfText, error) {
+	return retryPDFExtraction(ctx, func(ctx context.Context) (pdfText, error) {
+		return extractTextFromPDF(ctx, pdfBytes)
+	})
+}
+
+// retryPDFExtraction calls extract up to pdfExtractAttempts times while it
+// fails with a retryable error
+func retryPDFExtraction(ctx context.Context, extract func(context.Context) (pdfText, error)) (pdfText, error) {
+	var err error
+	for attempt := 1; attempt <= pdfExtractAttempts; attempt++ {
+		var extracted pdfText
+		extracted, err = extract(ctx)
+		if err == nil {
+			return extracted, nil
+		}
+		if !isRetryablePDFError(err) || attempt == pdfExtractAttempts {
+			break
+		}
+
+		rlog.Warn("retrying PDF text extraction", "attempt", attempt, "err", err)
+		select {
+		case <-ctx.Done():
+			return pdfText{}, ctx.Err()
+		case <-time.After(pdfRetryBackoff * time.Duration(attempt)):
+		}
+	}
+	return pdfText{}, err
+}
+
+// isRetryablePDFError reports whether a PDF extraction error may succeed on retry
+func isRetryablePDFError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, pdf.ErrInvalidPassword) || errors.Is(err, errTooManyFailedPages) {
+		return false
+	}
+
+	msg := err.Error()
+	for _, fatal := range []string{"not a PDF", "malformed", "unsupported PDF", "encrypt"} {
+		if strings.Contains(msg, fatal) {
+			return false
+		}
+	}
+	return true
+}
+
+// gradeAlternation is the regexp alternation of letter grades. Go's regexp
+// alternation is leftmost-first, so each plus variant is listed before its base
+// grade; otherwise "BA+" would be read as "BA".
+const gradeAlternation = `AA|BA\+|BB\+|CB\+|CC\+|DC\+|DD\+|BA|BB|CB|CC|DC|DD|FF|VF|BL|SG|DK|KL|--`
+
+// markerAlternation is the regexp alternation of the configured in-progress and
+// withdrawal markers that can be printed in a grade column, longest first so no
+// marker is cut short by another it starts with
+var markerAlternation = buildMarkerAlternation(grades.Markers())
+
+// buildMarkerAlternation quotes the printable markers into a regexp alternation.
+// Empty markers can't be matched and "--" is already a grade.
+func buildMarkerAlternation(markers []string) string {
+	var quoted []string
+	for _, marker := range markers {
+		if marker == "" || marker == "--" {
+			continue
+		}
+		quoted = append(quoted, regexp.QuoteMeta(marker))
+	}
+	sort.SliceStable(quoted, func(i, j int) bool {
+		return len(quoted[i]) > len(quoted[j])
+	})
+	return strings.Join(quoted, "|")
+}
+
+// findGrade returns the first standalone grade in courseText and its position;
+// start is -1 when there is none
+func findGrade(courseText string, profile *InstitutionProfile) (grade string, start int) {
+	match := profile.Rows.Grade.FindStringSubmatchIndex(courseText)
+	if match == nil {
+		return "", -1
+	}
+	return courseText[match[2]:match[3]], match[2]
+}
+
+// findLanguageMarker returns the language marker of a course row and its
+// position in courseText; start is -1 when the row has none
+func findLanguageMarker(courseText string, profile *InstitutionProfile) (marker string, start, end int) {
+	match := profile.Rows.LanguageMarker.FindStringSubmatchIndex(courseText)
+	if match == nil {
+		return "", -1, -1
+	}
+	return courseText[match[2]:match[3]], match[2], match[3]
+}
+
+// debugText returns the accumulated parser log when debug output was requested.
+// The log can run to several kilobytes, so it is left out by default.
+func debugText(enabled bool, debugInfo *strings.Builder) string {
+	if !enabled {
+		return ""
+	}
+	return debugInfo.String()
 }
 
 // parseTranscriptText parses the extracted text to find course information
-func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
+// using the layout of the given institution
+func parseTranscriptText(text string, profile *InstitutionProfile) ([]TranscriptCourse, string, error) {
 	var debugInfo strings.Builder
 	debugInfo.WriteString(fmt.Sprintf("Starting to parse transcript text, length: %d\n", len(text)))
+
+	// Some PDFs separate table columns with tabs, runs of spaces or non-breaking
+	// spaces; collapse them so the column patterns below see single spaces
+	text = normalizeWhitespace(text)
 	
-	// Search for semester patterns in the text - updated to include Yaz Okulu
-	// Combine both patterns: regular semesters and Yaz Okulu
-	semesterPattern := regexp.MustCompile(`(20\d{2}-20\d{2}\s+(Güz|Bahar|Yaz)\s+Dönemi|20\d{2}-20\d{2}\s+Yaz Okulu)`)
-	debugInfo.WriteString(fmt.Sprintf("Searching for semester pattern: %s\n", semesterPattern.String()))
-	semesterMatches := semesterPattern.FindAllStringIndex(text, -1)
+	// Search for semester patterns in the text
+	debugInfo.WriteString(fmt.Sprintf("Searching for semester pattern: %s\n", profile.SemesterPattern.String()))
+	semesterMatches := profile.SemesterPattern.FindAllStringIndex(text, -1)
 	
 	debugInfo.WriteString(fmt.Sprintf("Found %d semester matches\n", len(semesterMatches)))
 	
@@ -188,14 +769,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 	if len(semesterMatches) == 0 {
 		debugInfo.WriteString("No semester matches found, trying alternative patterns\n")
 		// Try alternative semester patterns that might be in the PDF
-		altPatterns := []*regexp.Regexp{
-			regexp.MustCompile(`(20\d{2}-20\d{2}\s+(Güz|Bahar|Yaz))`),
-			regexp.MustCompile(`(20\d{2}\s+(Güz|Bahar|Yaz))`),
-			regexp.MustCompile(`(Güz|Bahar|Yaz)\s+Dönemi`),
-			regexp.MustCompile(`(Yaz Okulu)`),
-		}
-		
-		for i, pattern := range altPatterns {
+		for i, pattern := range profile.AltSemesterPatterns {
 			debugInfo.WriteString(fmt.Sprintf("Trying alt pattern %d: %s\n", i+1, pattern.String()))
 			matches := pattern.FindAllStringIndex(text, -1)
 			debugInfo.WriteString(fmt.Sprintf("Alt pattern %d found %d matches\n", i+1, len(matches)))
@@ -209,13 +783,13 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 		// If still no matches, try to find any course codes and create a generic semester
 		if len(semesterMatches) == 0 {
 			debugInfo.WriteString("No semester patterns found, looking for course codes\n")
-			courseCodePattern := regexp.MustCompile(`[A-Z]{3}\s+\d{3}[A-Z]*`)
+			courseCodePattern := profile.CourseCodes.Loose
 			debugInfo.WriteString(fmt.Sprintf("Searching for course code pattern: %s\n", courseCodePattern.String()))
 			courseMatches := courseCodePattern.FindAllStringIndex(text, -1)
 			debugInfo.WriteString(fmt.Sprintf("Found %d course codes without semester\n", len(courseMatches)))
 			if len(courseMatches) > 0 {
 				// Found course codes but no semester, create a generic response
-				return createGenericCourses(text), debugInfo.String(), nil
+				return createGenericCourses(text, profile), debugInfo.String(), nil
 			}
 			
 			// No course codes found either
@@ -245,52 +819,18 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 		var cleanedLines []string
 		
 		// Check if this is a Yaz Okulu semester - they have different formatting
-		isYazOkulu := strings.Contains(semester, "Yaz Okulu")
+		isYazOkulu := profile.SummerSemesterPattern.MatchString(semester)
 		
 		for _, line := range lines {
-			// For Yaz Okulu semesters, be much more conservative with filtering
+			// For Yaz Okulu semesters, be much more conservative with filtering:
+			// only skip table headers and page header/footer lines and keep everything else.
+			// For regular semesters, also skip the semester summary lines.
 			if isYazOkulu {
-				// For Yaz Okulu, only skip the most obvious header lines and keep everything else
-				if strings.Contains(line, "Dersin Statüsü") || 
-				   strings.Contains(line, "Öğretim Dili") || 
-				   strings.Contains(line, "T U UK") || 
-				   strings.Contains(line, "AKTS") || 
-				   strings.Contains(line, "Not") || 
-				   strings.Contains(line, "Puan") || 
-				   strings.Contains(line, "Açıklama") ||
-				   strings.Contains(line, "Öğrenci No") ||
-				   strings.Contains(line, "T.C. Kimlik No") ||
-				   strings.Contains(line, "Adı") ||
-				   strings.Contains(line, "Doğum Tarihi") ||
-				   strings.Contains(line, "Soyadı") ||
-				   strings.Contains(line, "İSTANBUL TEKNİK ÜNİVERSİTESİ") ||
-				   strings.Contains(line, "NOT DÖKÜM BELGESİ") ||
-				   strings.Contains(line, "Belge Tarihi") ||
-				   strings.Contains(line, "YOKTR") ||
-				   strings.Contains(line, "www.turkiye.gov.tr") ||
-				   strings.Contains(line, "Bu belgenin doğruluğunu") ||
-				   strings.Contains(line, "SON SATIR") ||
-				   strings.Contains(line, "Bu satırdan sonra") {
-					continue
-				}
-			} else {
-				// For regular semesters, use the original filtering logic
-				if strings.Contains(line, "Dersin Statüsü") || 
-				   strings.Contains(line, "Öğretim Dili") || 
-				   strings.Contains(line, "T U UK") || 
-				   strings.Contains(line, "AKTS") || 
-				   strings.Contains(line, "Not") || 
-				   strings.Contains(line, "Puan") || 
-				   strings.Contains(line, "Açıklama") || 
-				   strings.Contains(line, "DNO:") || 
-				   strings.Contains(line, "GNO:") || 
-				   strings.Contains(line, "TUK:") || 
-				   strings.Contains(line, "TAKTS:") || 
-				   strings.Contains(line, "DSD:") || 
-				   strings.Contains(line, "Başarılı") || 
-				   strings.Contains(line, "Pass") {
+				if matchesAny(line, profile.TableHeaderPatterns) || matchesAny(line, profile.PageLinePatterns) {
 					continue
 				}
+			} else if matchesAny(line, profile.TableHeaderPatterns) || matchesAny(line, profile.SummaryLinePatterns) {
+				continue
 			}
 			
 			if strings.TrimSpace(line) == "" {
@@ -304,13 +844,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 			// Try a less aggressive cleaning approach
 			for _, line := range lines {
 				// Only skip obvious header lines, keep everything else
-				if strings.Contains(line, "Dersin Statüsü") || 
-				   strings.Contains(line, "Öğretim Dili") || 
-				   strings.Contains(line, "T U UK") || 
-				   strings.Contains(line, "AKTS") || 
-				   strings.Contains(line, "Not") || 
-				   strings.Contains(line, "Puan") || 
-				   strings.Contains(line, "Açıklama") {
+				if matchesAny(line, profile.TableHeaderPatterns) {
 					continue
 				}
 				if strings.TrimSpace(line) == "" {
@@ -343,18 +877,18 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 		// Look for course codes with asterisk prefix and proper format
 		// The pattern should match course codes like "ATA 121", "BLG 102E", "EKO 201E"
 		// Use word boundaries to ensure we don't capture part of the course name
-		courseCodePattern := regexp.MustCompile(`(\*?\s*[A-Z]{3}\s+\d{3}[A-Z]?)(?:\s|$)`)
+		courseCodePattern := profile.CourseCodes.Marked
 		courseMatches := courseCodePattern.FindAllStringIndex(cleanedText, -1)
 		
 		// If no matches, try a simpler pattern
 		if len(courseMatches) == 0 {
-			courseCodePattern = regexp.MustCompile(`([A-Z]{3}\s+\d{3}[A-Z]?)(?:\s|$)`)
+			courseCodePattern = profile.CourseCodes.Bounded
 			courseMatches = courseCodePattern.FindAllStringIndex(cleanedText, -1)
 		}
 		
 		// If still no matches, try a more flexible pattern that doesn't require word boundaries
 		if len(courseMatches) == 0 {
-			courseCodePattern = regexp.MustCompile(`([A-Z]{3}\s+\d{3}[A-Z]?)`)
+			courseCodePattern = profile.CourseCodes.Bare
 			courseMatches = courseCodePattern.FindAllStringIndex(cleanedText, -1)
 		}
 		
@@ -362,7 +896,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 		// This handles cases where the text is not properly split by lines
 		if len(courseMatches) == 0 {
 			debugInfo.WriteString(fmt.Sprintf("DEBUG: Semester '%s' - No course matches in cleaned text, trying raw semester text\n", semester))
-			courseCodePattern = regexp.MustCompile(`([A-Z]{3}\s+\d{3}[A-Z]?)`)
+			courseCodePattern = profile.CourseCodes.Bare
 			courseMatches = courseCodePattern.FindAllStringIndex(semesterText, -1)
 			if len(courseMatches) > 0 {
 				debugInfo.WriteString(fmt.Sprintf("DEBUG: Semester '%s' - Found %d course matches in raw semester text\n", semester, len(courseMatches)))
@@ -375,7 +909,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 		if len(courseMatches) == 0 && isYazOkulu {
 			debugInfo.WriteString(fmt.Sprintf("DEBUG: Semester '%s' - No course matches in cleaned text, trying raw text\n", semester))
 			// Try to find course patterns in the raw semester text for Yaz Okulu
-			courseCodePattern = regexp.MustCompile(`([A-Z]{3}\s+\d{3}[A-Z]?)`)
+			courseCodePattern = profile.CourseCodes.Bare
 			courseMatches = courseCodePattern.FindAllStringIndex(semesterText, -1)
 			debugInfo.WriteString(fmt.Sprintf("DEBUG: Semester '%s' - Found %d course matches in raw text\n", semester, len(courseMatches)))
 			if len(courseMatches) > 0 {
@@ -393,8 +927,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 		if len(courseMatches) == 0 {
 			debugInfo.WriteString(fmt.Sprintf("DEBUG: Semester '%s' - Trying more flexible pattern for Yaz Okulu\n", semester))
 			// Try a more flexible pattern that might catch different formats
-			flexiblePattern := regexp.MustCompile(`([A-Z]{2,4}\s+\d{2,4}[A-Z]?)`)
-			courseMatches = flexiblePattern.FindAllStringIndex(semesterText, -1)
+			courseMatches = profile.CourseCodes.Flexible.FindAllStringIndex(semesterText, -1)
 			debugInfo.WriteString(fmt.Sprintf("DEBUG: Semester '%s' - Found %d course matches with flexible pattern\n", semester, len(courseMatches)))
 			if len(courseMatches) > 0 {
 				usingRawText = true
@@ -406,7 +939,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 		// Debug: Check if course patterns were found
 		if len(courseMatches) == 0 {
 			// Try a simpler course pattern
-			simpleCoursePattern := regexp.MustCompile(`[A-Z]{3}\s+\d{3}[A-Z]*`)
+			simpleCoursePattern := profile.CourseCodes.Loose
 			var textToSearch string
 			if usingRawText {
 				textToSearch = semesterText
@@ -444,12 +977,11 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 			if len(codeParts) >= 2 {
 				// First part should be the department code (3 letters)
 				// Second part should be the course number (3 digits + optional letter)
-				if len(codeParts[0]) == 3 && len(codeParts[1]) >= 3 {
+				if profile.CourseCodes.Department.MatchString(codeParts[0]) && len(codeParts[1]) >= 3 {
 					// Extract just the course number part (3 digits + optional letter)
 					courseNum := codeParts[1]
 					// Find where the course number ends (3 digits + optional letter)
-					courseNumPattern := regexp.MustCompile(`^\d{3}[A-Z]?`)
-					if match := courseNumPattern.FindString(courseNum); match != "" {
+					if match := profile.CourseCodes.Number.FindString(courseNum); match != "" {
 						code = codeParts[0] + " " + match
 					} else {
 						// Fallback: keep only the department code and first 4 characters of course number
@@ -496,39 +1028,17 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 			debugInfo.WriteString(fmt.Sprintf("DEBUG: Processing course code '%s', course text length: %d\n", code, len(courseText)))
 			
 			// Clean up course text - remove footer content
-			// Look for the first occurrence of footer indicators and cut off there
-			footerIndicators := []string{
-				"www.turkiye.gov.tr", "Öğrenci No", "T.C. Kimlik No", "SELİM DİLŞAD", 
-				"ERCAN", "İSTANBUL TEKNİK ÜNİVERSİTESİ", "NOT DÖKÜM BELGESİ", 
-				"YOKTR4QWO3AEMVO0BT", "Ders kodunun başında * olan dersler",
-			}
-			
-			// For Yaz Okulu semesters, be more conservative with footer cleaning
-			if !isYazOkulu {
-				for _, indicator := range footerIndicators {
-					if idx := strings.Index(courseText, indicator); idx != -1 {
-						courseText = strings.TrimSpace(courseText[:idx])
-						break
-					}
-				}
+			// Cut off at the earliest footer match; Yaz Okulu semesters use a more
+			// conservative list since their rows are laid out less predictably
+			if isYazOkulu {
+				courseText = cutAtFirstMatch(courseText, profile.SummerFooterPatterns)
 			} else {
-				// For Yaz Okulu, only remove very obvious footer content
-				strictFooterIndicators := []string{
-					"www.turkiye.gov.tr", "NOT DÖKÜM BELGESİ", 
-					"YOKTR4QWO3AEMVO0BT", "Ders kodunun başında * olan dersler",
-				}
-				for _, indicator := range strictFooterIndicators {
-					if idx := strings.Index(courseText, indicator); idx != -1 {
-						courseText = strings.TrimSpace(courseText[:idx])
-						break
-					}
-				}
+				courseText = cutAtFirstMatch(courseText, profile.FooterPatterns)
 			}
 			
 					// Skip if no course data found - look for language patterns
 		// Also check for garbled versions of the language patterns
-		languagePattern := regexp.MustCompile(`(Tr|İng\.|Tr|İng)`)
-		if !languagePattern.MatchString(courseText) {
+		if _, markerStart, _ := findLanguageMarker(courseText, profile); markerStart < 0 {
 			debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - No language pattern found, skipping\n", code))
 			continue
 		}
@@ -537,16 +1047,40 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 		// Look for the language pattern followed by numbers, allowing for newlines and flexible spacing
 		// Pattern: Language + T U UK AKTS Grade Points Comment
 		// Also handle garbled versions of the language patterns
-		languageDataPattern := regexp.MustCompile(`(Tr|İng\.|Tr|İng)\s*(\d+)\s*(\d+)\s*(\d+\.?\d*)\s*(\d+\.?\d*)\s*(AA|BA\+?|BB\+?|CB\+?|CC\+?|DC\+?|DD\+?|BA|BB|CB|CC|DC|DD|FF|VF|BL|SG|DK|KL|--)\s*(\d+\.?\d*)(?:\s*([A-Z]{2}|--))?`)
-		languageDataMatch := languageDataPattern.FindStringSubmatch(courseText)
+		languageDataMatch := profile.Rows.LanguageData.FindStringSubmatch(courseText)
 		
 		debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Language data match: %v\n", code, languageDataMatch != nil))
 		
+		// Transferred (exchange) courses may leave the UK column blank while AKTS is
+		// present: Language + T U AKTS Grade. Use AKTS as the credits in that case
+		// rather than falling through to a 0-credit course.
+		if languageDataMatch == nil {
+			if ectsMatch := profile.Rows.ECTSOnly.FindStringSubmatchIndex(courseText); ectsMatch != nil {
+				ects := courseText[ectsMatch[8]:ectsMatch[9]]
+				grade := courseText[ectsMatch[10]:ectsMatch[11]]
+				name := cleanCourseName(courseText[:ectsMatch[0]], courseText)
+				debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - UK column blank, using AKTS '%s' as credits\n", code, ects))
+
+				results = append(results, TranscriptCourse{
+					Semester:        semester,
+					Code:            code,
+					Name:            name,
+					Credits:         ects,
+					ECTS:            ects,
+					Grade:           grade,
+					LessonID:        "",
+					ParseSource:     "ectsOnly",
+					RawText:         courseText,
+					CreditsFromECTS: true,
+				})
+				continue
+			}
+		}
+
 		// If the complex pattern fails, try a simpler approach
 		if languageDataMatch == nil {
 			// Try to find just the grade pattern
-			gradePattern := regexp.MustCompile(`(AA|BA\+?|BB\+?|CB\+?|CC\+?|DC\+?|DD\+?|BA|BB|CB|CC|DC|DD|FF|VF|BL|SG|DK|KL|--)`)
-			gradeMatch := gradePattern.FindString(courseText)
+			gradeMatch, gradeStart := findGrade(courseText, profile)
 			if gradeMatch != "" {
 				debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Found grade '%s' with simple pattern\n", code, gradeMatch))
 				
@@ -555,8 +1089,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 				// Pattern: language followed by numbers (T, U, UK, AKTS columns)
 				// Format: İng.32488 or Tr20020 (language + numbers stuck together)
 				// UK column is the 3rd number group (4th capture group), can be decimal like 1.5
-				ukCreditPattern := regexp.MustCompile(`(Tr|İng\.)(\d)(\d)(\d(?:\.\d)?)(\d+(?:\.\d+)?)`)
-				ukCreditMatch := ukCreditPattern.FindStringSubmatch(courseText)
+				ukCreditMatch := profile.Rows.UKCredit.FindStringSubmatch(courseText)
 				var credits string
 				if ukCreditMatch != nil && len(ukCreditMatch) >= 6 {
 					// Extract the UK column value (4th capture group)
@@ -579,8 +1112,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 					debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Found UK value: '%s', extracted credits: '%s'\n", code, ukValue, credits))
 				} else {
 					// Fallback to old pattern if UK pattern doesn't match
-					creditPattern := regexp.MustCompile(`(Tr|İng\.)\s*([0-9]+\.?[0-9]*)`)
-					creditMatch := creditPattern.FindStringSubmatch(courseText)
+					creditMatch := profile.Rows.Credit.FindStringSubmatch(courseText)
 					if creditMatch != nil && len(creditMatch) >= 3 {
 						fullNumber := creditMatch[2]
 						if len(fullNumber) > 0 {
@@ -616,15 +1148,12 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 				// Try to extract course name (everything before the language code)
 				name := "Unknown Course"
 				
-				// First, try to find the language pattern and extract everything before it
-				langPattern := regexp.MustCompile(`(Tr|İng\.)`)
-				langMatches := langPattern.FindAllStringIndex(courseText, -1)
-				debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Language matches: %v\n", code, langMatches))
+				// First, find the language column and extract everything before it
+				marker, markerStart, _ := findLanguageMarker(courseText, profile)
+				debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Language marker '%s' at %d\n", code, marker, markerStart))
 				debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Course text: '%s'\n", code, courseText))
-				if len(langMatches) > 0 {
-					// Use the first language occurrence (usually the one after the course name)
-					langMatch := langMatches[0]
-					namePart := courseText[:langMatch[0]]
+				if markerStart >= 0 {
+					namePart := courseText[:markerStart]
 					debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Name part before language: '%s'\n", code, namePart))
 					name = strings.TrimSpace(namePart)
 					
@@ -649,7 +1178,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 				} else {
 					// Fallback: try to extract from before the grade
 					if gradeMatch != "" {
-						namePart := courseText[:strings.Index(courseText, gradeMatch)]
+						namePart := courseText[:gradeStart]
 						name = strings.TrimSpace(namePart)
 						if name == "" {
 							name = "Unknown Course"
@@ -670,7 +1199,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 				// Check if this is a Turkish course or specific English course (ING 100E) and correct the course code
 				finalCode := code
 				finalName := name
-				if strings.Contains(courseText, "Tr") || (strings.HasPrefix(code, "ING 100") && strings.Contains(courseText, "İng.")) {
+				if marker == "Tr" || (strings.HasPrefix(code, "ING 100") && strings.HasPrefix(marker, "İng")) {
 					// Extract department code and course number without letter suffix
 					codeParts := strings.Fields(code)
 					if len(codeParts) >= 2 {
@@ -684,14 +1213,14 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 							if len(courseNum) > 3 {
 								removedLetter := courseNum[3:4] // Get the letter after the 3 digits
 								finalName = removedLetter + name
-								debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - %s course detected, corrected code to '%s', name to '%s'\n", code, func() string { if strings.Contains(courseText, "Tr") { return "Turkish" } else { return "ING 100" } }(), finalCode, finalName))
+								debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - %s course detected, corrected code to '%s', name to '%s'\n", code, func() string { if marker == "Tr" { return "Turkish" } else { return "ING 100" } }(), finalCode, finalName))
 							}
 						}
 					}
 				}
 				
 				// Check if this is a laboratory course and add 'L' suffix to course code
-				if strings.Contains(strings.ToLower(name), "laboratory") || strings.Contains(strings.ToLower(name), "lab") {
+				if isLabCourse(name) {
 					// Add 'L' suffix to the course code if it doesn't already have it
 					if !strings.HasSuffix(finalCode, "L") {
 						finalCode = finalCode + "L"
@@ -700,19 +1229,20 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 				}
 				
 				results = append(results, TranscriptCourse{
-					Semester: semester,
-					Code:     finalCode,
-					Name:     finalName,
-					Credits:  credits,
-					Grade:    gradeMatch,
-					LessonID: "",
+					Semester:    semester,
+					Code:        finalCode,
+					Name:        finalName,
+					Credits:     credits,
+					Grade:       gradeMatch,
+					LessonID:    "",
+					ParseSource: "simplePattern",
+					RawText:     courseText,
 				})
 				continue
 			}
 		}
 		
 		if languageDataMatch != nil {
-				language := strings.TrimSpace(languageDataMatch[1])
 				localCredits := languageDataMatch[4]
 				grade := strings.TrimSpace(languageDataMatch[6])
 				
@@ -720,8 +1250,10 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 				debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Language data match groups: %v\n", code, languageDataMatch))
 				debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Raw localCredits: '%s'\n", code, localCredits))
 				
-				// Everything before the language is the course name
-				namePart := courseText[:strings.Index(courseText, language)]
+				// Everything before the language column is the course name. The column
+				// is located by the whole row match, since the name itself can contain
+				// the marker ("Transportation")
+				namePart := courseText[:profile.Rows.LanguageData.FindStringIndex(courseText)[0]]
 				namePart = strings.TrimSpace(namePart)
 				
 				// Clean up the name - remove English translations in parentheses and newlines
@@ -763,7 +1295,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 				// Check if this is a Turkish course and correct the course code
 				finalCode := code
 				finalName := name
-				if strings.Contains(courseText, "Tr") {
+				if marker, _, _ := findLanguageMarker(courseText, profile); marker == "Tr" {
 					// Extract department code and course number without letter suffix
 					codeParts := strings.Fields(code)
 					if len(codeParts) >= 2 {
@@ -783,7 +1315,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 				}
 				
 				// Check if this is a laboratory course and add 'L' suffix to course code
-				if strings.Contains(strings.ToLower(name), "laboratory") || strings.Contains(strings.ToLower(name), "lab") {
+				if isLabCourse(name) {
 					// Add 'L' suffix to the course code if it doesn't already have it
 					if !strings.HasSuffix(finalCode, "L") {
 						finalCode = finalCode + "L"
@@ -791,27 +1323,25 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 				}
 				
 				results = append(results, TranscriptCourse{
-					Semester: semester,
-					Code:     finalCode,
-					Name:     finalName,
-					Credits:  credits,
-					Grade:    grade,
-					LessonID: "",
+					Semester:    semester,
+					Code:        finalCode,
+					Name:        finalName,
+					Credits:     credits,
+					ECTS:        strings.TrimSpace(languageDataMatch[5]),
+					Points:      strings.TrimSpace(languageDataMatch[7]),
+					Grade:       grade,
+					LessonID:    "",
+					ParseSource: "complexPattern",
+					RawText:     courseText,
 				})
 			} else {
 				// Try a simpler approach - just find the language and then look for numbers
-				// Find all language occurrences
-				langPattern := regexp.MustCompile(`(Tr|İng\.)`)
-				langMatches := langPattern.FindAllStringIndex(courseText, -1)
-				debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Language pattern matches: %v\n", code, langMatches))
-				if len(langMatches) > 0 {
-					// Use the last language occurrence (usually the one before the data)
-					langMatch := langMatches[len(langMatches)-1]
-					language := courseText[langMatch[0]:langMatch[1]]
-					language = strings.TrimSpace(language)
-					
+				// Find the language column
+				language, markerStart, markerEnd := findLanguageMarker(courseText, profile)
+				debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Language marker '%s' at %d\n", code, language, markerStart))
+				if markerStart >= 0 {
 					// Get text after language
-					afterLang := strings.TrimSpace(courseText[langMatch[1]:])
+					afterLang := strings.TrimSpace(courseText[markerEnd:])
 					
 					// Try to extract numbers manually by splitting
 					parts := strings.Fields(afterLang)
@@ -825,22 +1355,13 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 						points := parts[5]
 						
 						// Validate grade format
-						validGrades := []string{"AA", "BA+", "BA", "BB+", "BB", "CB+", "CB", "CC+", "CC", "DC+", "DC", "DD+", "DD", "FF", "VF", "BL", "SG", "DK", "KL", "--"}
-						isValidGrade := false
-						for _, validGrade := range validGrades {
-							if grade == validGrade {
-								isValidGrade = true
-								break
-							}
-						}
-						
-						if !isValidGrade {
+						if !profile.Rows.ExactGrade.MatchString(grade) {
 							// They might be swapped
 							points, grade = grade, points
 						}
 						
 						// Everything before the language is the course name
-						namePart := strings.TrimSpace(courseText[:langMatch[0]])
+						namePart := strings.TrimSpace(courseText[:markerStart])
 						name := regexp.MustCompile(`\s*\([^)]*\)\s*`).ReplaceAllString(namePart, "")
 						name = regexp.MustCompile(`\s+`).ReplaceAllString(name, " ")
 						name = strings.TrimSpace(name)
@@ -899,22 +1420,11 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 						finalCode := code
 						finalName := name
 						
-						// Check if this is a Turkish course by looking at the language matches
-						// Turkish courses have "Tr" in their language pattern
-						languagePattern := regexp.MustCompile(`(Tr|İng\.|Tr|İng)`)
-						langMatches := languagePattern.FindAllStringIndex(courseText, -1)
-						isTurkishCourse := false
-						
-						debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Checking for Turkish course, found %d language matches\n", code, len(langMatches)))
-						
-						for _, langMatch := range langMatches {
-							langText := courseText[langMatch[0]:langMatch[1]]
-							debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Language match: '%s'\n", code, langText))
-							if langText == "Tr" {
-								isTurkishCourse = true
-								debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Found Turkish language marker\n", code))
-								break
-							}
+						// Check if this is a Turkish course by looking at the language column
+						// Turkish courses have "Tr" in their language column
+						isTurkishCourse := language == "Tr"
+						if isTurkishCourse {
+							debugInfo.WriteString(fmt.Sprintf("DEBUG: Course '%s' - Found Turkish language marker\n", code))
 						}
 						
 						if isTurkishCourse {
@@ -940,7 +1450,7 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 						}
 						
 						// Check if this is a laboratory course and add 'L' suffix to course code
-						if strings.Contains(strings.ToLower(name), "laboratory") || strings.Contains(strings.ToLower(name), "lab") {
+						if isLabCourse(name) {
 							// Add 'L' suffix to the course code if it doesn't already have it
 							if !strings.HasSuffix(finalCode, "L") {
 								finalCode = finalCode + "L"
@@ -949,12 +1459,14 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 						}
 						
 						results = append(results, TranscriptCourse{
-							Semester: semester,
-							Code:     finalCode,
-							Name:     name,
-							Credits:  credits,
-							Grade:    grade,
-							LessonID: "",
+							Semester:    semester,
+							Code:        finalCode,
+							Name:        name,
+							Credits:     credits,
+							Grade:       grade,
+							LessonID:    "",
+							ParseSource: "fallbackParts",
+							RawText:     courseText,
 						})
 					}
 				}
@@ -966,12 +1478,85 @@ func parseTranscriptText(text string) ([]TranscriptCourse, string, error) {
 	return results, debugInfo.String(), nil
 }
 
+// mojibakeReplacer maps Turkish characters that were UTF-8 encoded but decoded
+// as Latin-1/Windows-1252 back to the intended characters
+var mojibakeReplacer = strings.NewReplacer(
+	"Ã§", "ç", "Ã‡", "Ç",
+	"ÄŸ", "ğ", "Äž", "Ğ",
+	"Ä±", "ı", "Ä°", "İ",
+	"Ã¶", "ö", "Ã–", "Ö",
+	"ÅŸ", "ş", "Åž", "Ş",
+	"Ã¼", "ü", "Ãœ", "Ü",
+)
+
+// repairText drops invalid UTF-8 sequences and undoes common Turkish mojibake,
+// reporting whether the text had to be changed
+func repairText(text string) (string, bool) {
+	repaired := text
+	if !utf8.ValidString(repaired) {
+		repaired = strings.ToValidUTF8(repaired, "")
+	}
+	repaired = mojibakeReplacer.Replace(repaired)
+	return repaired, repaired != text
+}
+
+// horizontalSpacePattern matches runs of spaces, tabs and non-breaking spaces
+var horizontalSpacePattern = regexp.MustCompile(`[ \t\f\v\x{00A0}]+`)
+
+// normalizeWhitespace collapses runs of horizontal whitespace to a single space
+// while keeping line breaks intact
+func normalizeWhitespace(text string) string {
+	return horizontalSpacePattern.ReplaceAllString(text, " ")
+}
+
+// matchesAny reports whether line matches any of the patterns
+func matchesAny(line string, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// cutAtFirstMatch truncates text at the earliest match of any pattern
+func cutAtFirstMatch(text string, patterns []*regexp.Regexp) string {
+	cut := len(text)
+	for _, pattern := range patterns {
+		if loc := pattern.FindStringIndex(text); loc != nil && loc[0] < cut {
+			cut = loc[0]
+		}
+	}
+	return strings.TrimSpace(text[:cut])
+}
+
+// cleanCourseName turns the raw text preceding the language column into a course name,
+// dropping parenthesised translations and the 'L' prefix of laboratory courses
+func cleanCourseName(namePart, courseText string) string {
+	name := regexp.MustCompile(`\s*\([^)]*\)\s*`).ReplaceAllString(namePart, "")
+	name = regexp.MustCompile(`\s+`).ReplaceAllString(name, " ")
+	name = strings.TrimSpace(name)
+
+	// If name is empty, try to extract from parentheses
+	if name == "" {
+		parenMatches := regexp.MustCompile(`\(([^)]+)\)`).FindStringSubmatch(courseText)
+		if len(parenMatches) > 1 {
+			name = strings.TrimSpace(parenMatches[1])
+		}
+	}
+
+	if len(name) > 1 && name[0] == 'L' && name[1] >= 'A' && name[1] <= 'Z' {
+		name = name[1:]
+	}
+	return name
+}
+
 // createGenericCourses creates courses when semester information is not found
-func createGenericCourses(text string) []TranscriptCourse {
+func createGenericCourses(text string, profile *InstitutionProfile) []TranscriptCourse {
 	var results []TranscriptCourse
 	
-	// Find all course codes in the text
-	courseCodePattern := regexp.MustCompile(`(\*?\s*[A-Z]{3}\s+\d{3}[A-Z]*)`)
+	// Find all course codes in the text, with any asterisk prefix
+	courseCodePattern := regexp.MustCompile(`\*?\s*(?:` + profile.CourseCodes.Loose.String() + `)`)
 	courseMatches := courseCodePattern.FindAllStringIndex(text, -1)
 	
 	for i, courseMatch := range courseMatches {
@@ -990,19 +1575,20 @@ func createGenericCourses(text string) []TranscriptCourse {
 		
 		// Try to extract basic course information
 		// Look for common patterns in the course text
-		gradePattern := regexp.MustCompile(`(AA|BA\+?|BB\+?|CB\+?|CC\+?|DC\+?|DD\+?|BA|BB|CB|CC|DC|DD|FF|VF|BL|SG|DK|KL|--)`)
-		gradeMatch := gradePattern.FindString(courseText)
+		gradeMatch, gradeStart := findGrade(courseText, profile)
 		
 		// Look for credit patterns (numbers that could be credits)
 		creditPattern := regexp.MustCompile(`(\d+\.?\d*)`)
 		creditMatches := creditPattern.FindAllString(courseText, -1)
 		
-		grade := "N/A"
+		// Leave unread fields empty: an empty grade is an in-progress marker and
+		// empty credits are reported as unparsed by the GPA utilities
+		grade := ""
 		if gradeMatch != "" {
 			grade = gradeMatch
 		}
 		
-		credits := "N/A"
+		credits := ""
 		if len(creditMatches) > 0 {
 			credits = creditMatches[0] // Use first number as credits
 		}
@@ -1010,7 +1596,7 @@ func createGenericCourses(text string) []TranscriptCourse {
 		// Try to extract course name (everything before the first grade or credit)
 		name := "Unknown Course"
 		if gradeMatch != "" {
-			namePart := courseText[:strings.Index(courseText, gradeMatch)]
+			namePart := courseText[:gradeStart]
 			name = strings.TrimSpace(namePart)
 			if name == "" {
 				name = "Unknown Course"
@@ -1034,7 +1620,7 @@ func createGenericCourses(text string) []TranscriptCourse {
 		// For Turkish courses (marked with "Tr") and English courses (marked with "İng."), remove the letter suffix from course code
 		finalCode := code
 		finalName := name
-		if strings.Contains(courseText, "Tr") || strings.Contains(courseText, "İng.") {
+		if _, markerStart, _ := findLanguageMarker(courseText, profile); markerStart >= 0 {
 			// Extract department code and course number without letter suffix
 			codeParts := strings.Fields(code)
 			if len(codeParts) >= 2 {
@@ -1054,7 +1640,7 @@ func createGenericCourses(text string) []TranscriptCourse {
 		}
 		
 		// Check if this is a laboratory course and add 'L' suffix to course code
-		if strings.Contains(strings.ToLower(name), "laboratory") || strings.Contains(strings.ToLower(name), "lab") {
+		if isLabCourse(name) {
 			// Add 'L' suffix to the course code if it doesn't already have it
 			if !strings.HasSuffix(finalCode, "L") {
 				finalCode = finalCode + "L"
@@ -1062,12 +1648,14 @@ func createGenericCourses(text string) []TranscriptCourse {
 		}
 		
 		results = append(results, TranscriptCourse{
-			Semester: "Unknown Semester",
-			Code:     finalCode,
-			Name:     finalName,
-			Credits:  credits,
-			Grade:    grade,
-			LessonID: "",
+			Semester:    "Unknown Semester",
+			Code:        finalCode,
+			Name:        finalName,
+			Credits:     credits,
+			Grade:       grade,
+			LessonID:    "",
+			ParseSource: "genericNoSemester",
+			RawText:     courseText,
 		})
 	}
 	
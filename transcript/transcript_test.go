@@ -0,0 +1,692 @@
+package transcript
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/beta/errs"
+	"github.com/ledongthuc/pdf"
+)
+
+func TestBuildMarkerAlternation(t *testing.T) {
+	tests := []struct {
+		name    string
+		markers []string
+		want    string
+	}{
+		{"default markers", []string{"--", "", "Devam", "NG", "W"}, "Devam|NG|W"},
+		{"longer marker first", []string{"W", "WD"}, "WD|W"},
+		{"metacharacters quoted", []string{"W*"}, `W\*`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildMarkerAlternation(tt.markers); got != tt.want {
+				t.Errorf("buildMarkerAlternation(%q) = %q, want %q", tt.markers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePDFUploads(t *testing.T) {
+	// encoded returns a base64 payload that decodes to size bytes
+	encoded := func(size int) string {
+		return strings.Repeat("A", base64.StdEncoding.EncodedLen(size))
+	}
+	// Base64 decodes in blocks of three bytes
+	limit := maxTotalPDFSizeBytes / 3 * 3
+
+	tests := []struct {
+		name    string
+		pdfs    []string
+		wantErr bool
+	}{
+		{"single upload", nil, false},
+		{"files within the limits", []string{encoded(1 << 20), encoded(1 << 20)}, false},
+		{"too many files", make([]string, maxPDFFiles+1), true},
+		{"combined size at the limit", []string{encoded(limit - 3), encoded(3)}, false},
+		{"combined size over the limit", []string{encoded(limit), encoded(3)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePDFUploads(tt.pdfs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validatePDFUploads() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var apiErr *errs.Error
+			if err != nil && (!errors.As(err, &apiErr) || apiErr.Code != errs.InvalidArgument) {
+				t.Errorf("error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestExtractionContextError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode errs.ErrCode // OK when the error isn't a context error
+	}{
+		{"no error", nil, errs.OK},
+		{"unreadable PDF", errors.New("failed to create PDF reader"), errs.OK},
+		{"parse timeout", context.DeadlineExceeded, errs.DeadlineExceeded},
+		{"wrapped parse timeout", fmt.Errorf("page 3: %w", context.DeadlineExceeded), errs.DeadlineExceeded},
+		{"caller went away", context.Canceled, errs.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := extractionContextError(tt.err)
+			if tt.wantCode == errs.OK {
+				if err != nil {
+					t.Fatalf("extractionContextError(%v) = %v, want nil", tt.err, err)
+				}
+				return
+			}
+			var apiErr *errs.Error
+			if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+				t.Errorf("extractionContextError(%v) = %v, want code %v", tt.err, err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestParseTranscriptTextECTSOnlyRows(t *testing.T) {
+	tests := []struct {
+		name          string
+		row           string
+		wantCredits   string
+		wantECTS      string
+		wantFromECTS  bool
+		wantGrade     string
+		wantParseFrom string
+	}{
+		{"UK column present", "BLG 101E Introduction to Computing İng. 3 0 3 5 BB 3.00", "3", "5", false, "BB", ""},
+		{"UK column blank", "FIZ 101E Physics I İng. 3 0 6 BA", "6", "6", true, "BA", "ectsOnly"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			courses, _, err := parseTranscriptText("2022-2023 Güz Dönemi\n"+tt.row+"\n", defaultProfile())
+			if err != nil {
+				t.Fatalf("parseTranscriptText: %v", err)
+			}
+			if len(courses) != 1 {
+				t.Fatalf("parsed %d courses, want 1: %+v", len(courses), courses)
+			}
+			course := courses[0]
+			if course.Credits != tt.wantCredits || course.ECTS != tt.wantECTS || course.CreditsFromECTS != tt.wantFromECTS {
+				t.Errorf("credits %q, ECTS %q, from ECTS %v; want %q, %q, %v",
+					course.Credits, course.ECTS, course.CreditsFromECTS, tt.wantCredits, tt.wantECTS, tt.wantFromECTS)
+			}
+			if course.Grade != tt.wantGrade {
+				t.Errorf("grade = %q, want %q", course.Grade, tt.wantGrade)
+			}
+			if tt.wantParseFrom != "" && course.ParseSource != tt.wantParseFrom {
+				t.Errorf("parse source = %q, want %q", course.ParseSource, tt.wantParseFrom)
+			}
+		})
+	}
+}
+
+func TestNormalizeWhitespace(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"single spaces", "BLG 101E İng. 3 0 3 5 BB", "BLG 101E İng. 3 0 3 5 BB"},
+		{"tabs", "BLG 101E\tİng.\t3\t0", "BLG 101E İng. 3 0"},
+		{"runs of spaces", "BLG   101E    İng.", "BLG 101E İng."},
+		{"non-breaking spaces", "BLG\u00a0101E\u00a0\u00a0İng.", "BLG 101E İng."},
+		{"line breaks kept", "BLG 101E\t\n\tMAT 103E", "BLG 101E \n MAT 103E"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeWhitespace(tt.in); got != tt.want {
+				t.Errorf("normalizeWhitespace(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTranscriptTextSeparatedColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		row  string
+	}{
+		{"single spaces", "BLG 101E Introduction to Computing İng. 3 0 3 5 BB 3.00"},
+		{"tabs", "BLG 101E\tIntroduction to Computing\tİng.\t3\t0\t3\t5\tBB\t3.00"},
+		{"runs of spaces", "BLG 101E  Introduction to Computing    İng.   3  0  3  5   BB   3.00"},
+		{"non-breaking spaces", "BLG 101E\u00a0Introduction to Computing\u00a0İng.\u00a03\u00a00\u00a03\u00a05\u00a0BB\u00a03.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			courses, _, err := parseTranscriptText("2022-2023 Güz Dönemi\n"+tt.row+"\n", defaultProfile())
+			if err != nil {
+				t.Fatalf("parseTranscriptText: %v", err)
+			}
+			if len(courses) != 1 {
+				t.Fatalf("parsed %d courses, want 1: %+v", len(courses), courses)
+			}
+			course := courses[0]
+			if course.Code != "BLG 101E" || course.Name != "Introduction to Computing" || course.Credits != "3" || course.Grade != "BB" {
+				t.Errorf("parsed %q %q %q %q, want BLG 101E, Introduction to Computing, 3, BB",
+					course.Code, course.Name, course.Credits, course.Grade)
+			}
+		})
+	}
+}
+
+func TestRetryPDFExtraction(t *testing.T) {
+	defer func(backoff time.Duration) { pdfRetryBackoff = backoff }(pdfRetryBackoff)
+	pdfRetryBackoff = time.Millisecond
+
+	transient := errors.New("unexpected EOF reading xref")
+	tests := []struct {
+		name      string
+		failures  int // calls that fail before the reader succeeds
+		err       error
+		wantCalls int
+		wantErr   bool
+	}{
+		{"succeeds first time", 0, transient, 1, false},
+		{"fails once then succeeds", 1, transient, 2, false},
+		{"always fails", pdfExtractAttempts, transient, pdfExtractAttempts, true},
+		{"encrypted document", 1, pdf.ErrInvalidPassword, 1, true},
+		{"too many failed pages", 1, fmt.Errorf("%w: 3 of 4 pages", errTooManyFailedPages), 1, true},
+		{"malformed document", 1, errors.New("malformed PDF: missing trailer"), 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			extracted, err := retryPDFExtraction(context.Background(), func(context.Context) (pdfText, error) {
+				calls++
+				if calls <= tt.failures {
+					return pdfText{}, tt.err
+				}
+				return pdfText{text: "BLG 101E", pages: 1}, nil
+			})
+			if calls != tt.wantCalls {
+				t.Errorf("extract called %d times, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil || extracted.text != "BLG 101E" {
+				t.Errorf("retryPDFExtraction() = %+v, %v, want extracted text", extracted, err)
+			}
+		})
+	}
+}
+
+func TestRetryPDFExtractionStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := retryPDFExtraction(ctx, func(context.Context) (pdfText, error) {
+		calls++
+		cancel()
+		return pdfText{}, errors.New("unexpected EOF reading xref")
+	})
+	if calls != 1 || !errors.Is(err, context.Canceled) {
+		t.Errorf("calls = %d, err = %v, want 1 call and context.Canceled", calls, err)
+	}
+}
+
+func TestParseTranscriptTextNumberedSemesters(t *testing.T) {
+	text := "1. Yarıyıl\n" +
+		"BLG 101E Introduction to Computing İng. 3 0 3 5 BB 3.00\n" +
+		"2. Yarıyıl\n" +
+		"BLG 102E Introduction to Scientific and Engineering Computing İng. 3 0 3 5 CB 2.50\n"
+
+	courses, _, err := parseTranscriptText(text, defaultProfile())
+	if err != nil {
+		t.Fatalf("parseTranscriptText: %v", err)
+	}
+
+	tests := []struct {
+		code     string
+		semester string
+	}{
+		{"BLG 101E", "1. Yarıyıl"},
+		{"BLG 102E", "2. Yarıyıl"},
+	}
+	if len(courses) != len(tests) {
+		t.Fatalf("parsed %d courses, want %d: %+v", len(courses), len(tests), courses)
+	}
+	for i, tt := range tests {
+		if courses[i].Code != tt.code || courses[i].Semester != tt.semester {
+			t.Errorf("course %d = %s in %q, want %s in %q", i, courses[i].Code, courses[i].Semester, tt.code, tt.semester)
+		}
+	}
+}
+
+func TestDedupeCourses(t *testing.T) {
+	row := TranscriptCourse{Semester: "2022-2023 Güz Dönemi", Code: "BLG 101E", Name: "Introduction to Computing", Credits: "3", Grade: "BB"}
+	repeat := row
+	repeat.Grade = "FF"
+	other := row
+	other.Code = "MAT 103E"
+
+	tests := []struct {
+		name         string
+		courses      []TranscriptCourse
+		want         []TranscriptCourse
+		wantWarnings []string
+	}{
+		{"distinct courses", []TranscriptCourse{row, other}, []TranscriptCourse{row, other}, nil},
+		{"identical rows collapsed", []TranscriptCourse{row, other, row}, []TranscriptCourse{row, other}, []string{WarningDuplicateRemoved}},
+		{"in-term repeat kept", []TranscriptCourse{row, repeat}, []TranscriptCourse{row, repeat}, []string{WarningInTermRepeat}},
+		{
+			"repeat and its duplicate",
+			[]TranscriptCourse{row, repeat, repeat},
+			[]TranscriptCourse{row, repeat},
+			[]string{WarningInTermRepeat, WarningDuplicateRemoved},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := &ParseDiagnostics{}
+			got := dedupeCourses(tt.courses, diagnostics)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dedupeCourses() = %+v, want %+v", got, tt.want)
+			}
+			var kinds []string
+			for _, w := range diagnostics.Warnings {
+				kinds = append(kinds, w.Kind)
+			}
+			if !reflect.DeepEqual(kinds, tt.wantWarnings) {
+				t.Errorf("warnings = %q, want %q", kinds, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestRepairText(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		want        string
+		wantChanged bool
+	}{
+		{"clean text", "2022-2023 Güz Dönemi", "2022-2023 Güz Dönemi", false},
+		{"mojibake semester", "2022-2023 GÃ¼z DÃ¶nemi", "2022-2023 Güz Dönemi", true},
+		{"mojibake language marker", "BLG 101E Introduction to Computing Ä°ng. 3 0 3 5 BB", "BLG 101E Introduction to Computing İng. 3 0 3 5 BB", true},
+		{"mojibake lowercase", "Ä±ÅŸÄŸÃ§", "ışğç", true},
+		{"invalid UTF-8", "BLG\xff 101E", "BLG 101E", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := repairText(tt.in)
+			if got != tt.want || changed != tt.wantChanged {
+				t.Errorf("repairText(%q) = %q, %v; want %q, %v", tt.in, got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestParseTranscriptTextRepairedSample(t *testing.T) {
+	garbled := "2022-2023 GÃ¼z DÃ¶nemi\nBLG 101E Introduction to Computing Ä°ng. 3 0 3 5 BB 3.00\n"
+
+	text, changed := repairText(garbled)
+	if !changed {
+		t.Fatal("repairText reported no change for a garbled sample")
+	}
+	courses, _, err := parseTranscriptText(text, defaultProfile())
+	if err != nil {
+		t.Fatalf("parseTranscriptText: %v", err)
+	}
+	if len(courses) != 1 || courses[0].Semester != "2022-2023 Güz Dönemi" || courses[0].Code != "BLG 101E" {
+		t.Errorf("parsed %+v, want BLG 101E in 2022-2023 Güz Dönemi", courses)
+	}
+}
+
+func TestReadPages(t *testing.T) {
+	errPage := errors.New("invalid font encoding")
+	tests := []struct {
+		name        string
+		pages       []string
+		failing     map[int]bool
+		wantText    string
+		wantFailed  []int
+		wantTooMany bool
+	}{
+		{"all pages read", []string{"BLG 101E", "MAT 103E", "FIZ 101E"}, nil, "BLG 101E\nMAT 103E\nFIZ 101E\n", nil, false},
+		{"page 2 fails", []string{"BLG 101E", "MAT 103E", "FIZ 101E"}, map[int]bool{2: true}, "BLG 101E\nFIZ 101E\n", []int{2}, false},
+		{"blank page skipped", []string{"BLG 101E", "", "FIZ 101E"}, nil, "BLG 101E\nFIZ 101E\n", nil, false},
+		{"most pages fail", []string{"BLG 101E", "MAT 103E", "FIZ 101E"}, map[int]bool{2: true, 3: true}, "", []int{2, 3}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extracted, err := readPages(context.Background(), len(tt.pages), func(page int) (string, error) {
+				if tt.failing[page] {
+					return "", errPage
+				}
+				return tt.pages[page-1], nil
+			})
+			if tt.wantTooMany != errors.Is(err, errTooManyFailedPages) {
+				t.Fatalf("err = %v, want too many failed pages: %v", err, tt.wantTooMany)
+			}
+			if !tt.wantTooMany && err != nil {
+				t.Fatalf("readPages: %v", err)
+			}
+			if extracted.text != tt.wantText || extracted.pages != len(tt.pages) || !reflect.DeepEqual(extracted.failedPages, tt.wantFailed) {
+				t.Errorf("readPages() = %+v, want text %q, %d pages, failed %v", extracted, tt.wantText, len(tt.pages), tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestParseTranscriptTextPassFailGrades(t *testing.T) {
+	tests := []struct {
+		name  string
+		row   string
+		grade string
+	}{
+		{"Geçer", "ING 101 English I Tr 2 0 2 3 G 0.00", "G"},
+		{"Kalır", "ING 101 English I Tr 2 0 2 3 K 0.00", "K"},
+		{"blank UK column", "ING 101 English I Tr 2 0 3 G", "G"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			courses, _, err := parseTranscriptText("2022-2023 Güz Dönemi\n"+tt.row+"\n", defaultProfile())
+			if err != nil {
+				t.Fatalf("parseTranscriptText: %v", err)
+			}
+			if len(courses) != 1 || courses[0].Code != "ING 101" || courses[0].Grade != tt.grade {
+				t.Errorf("parsed %+v, want ING 101 graded %s", courses, tt.grade)
+			}
+		})
+	}
+}
+
+func TestParseTranscriptTextGenericFallback(t *testing.T) {
+	text := "BLG 101E Introduction to Computing\n" +
+		"MAT 103E Mathematics I 4 AA\n"
+
+	parsed, _, err := parseTranscriptText(text, defaultProfile())
+	if err != nil {
+		t.Fatalf("parseTranscriptText: %v", err)
+	}
+
+	tests := []struct {
+		code    string
+		credits string
+		grade   string
+	}{
+		{"BLG 101E", "", ""},
+		{"MAT 103E", "4", "AA"},
+	}
+	if len(parsed) != len(tests) {
+		t.Fatalf("parsed %d courses, want %d: %+v", len(parsed), len(tests), parsed)
+	}
+	for i, tt := range tests {
+		course := parsed[i]
+		// The fallback keeps the whitespace matched before a code; it's trimmed on store
+		if strings.TrimSpace(course.Code) != tt.code || course.Credits != tt.credits || course.Grade != tt.grade {
+			t.Errorf("course %d = %s credits %q grade %q, want %s credits %q grade %q",
+				i, course.Code, course.Credits, course.Grade, tt.code, tt.credits, tt.grade)
+		}
+	}
+
+	summary := CalculateGPASummary(toCourses(parsed))
+	if summary.GPA != 4 || summary.CourseCount != 1 || summary.Unparsed != 1 {
+		t.Errorf("summary = %+v, want GPA 4.00 over 1 course with 1 unparsed", summary)
+	}
+}
+
+func TestParseTranscriptTextEnglishTerms(t *testing.T) {
+	text := "2021-2022 Fall Semester\n" +
+		"BLG 101E Introduction to Computing İng. 3 0 3 5 BB 3.00\n" +
+		"2021-2022 Spring Semester\n" +
+		"BLG 102E Introduction to Scientific and Engineering Computing İng. 3 0 3 5 CB 2.50\n" +
+		"2021-2022 Summer School\n" +
+		"MAT 103E Mathematics I İng. 4 0 4 6 AA 4.00\n"
+
+	courses, _, err := parseTranscriptText(text, defaultProfile())
+	if err != nil {
+		t.Fatalf("parseTranscriptText: %v", err)
+	}
+
+	tests := []struct {
+		code     string
+		semester string
+	}{
+		{"BLG 101E", "2021-2022 Fall Semester"},
+		{"BLG 102E", "2021-2022 Spring Semester"},
+		{"MAT 103E", "2021-2022 Summer School"},
+	}
+	if len(courses) != len(tests) {
+		t.Fatalf("parsed %d courses, want %d: %+v", len(courses), len(tests), courses)
+	}
+	for i, tt := range tests {
+		if courses[i].Code != tt.code || courses[i].Semester != tt.semester {
+			t.Errorf("course %d = %s in %q, want %s in %q", i, courses[i].Code, courses[i].Semester, tt.code, tt.semester)
+		}
+	}
+}
+
+func TestFindLanguageMarker(t *testing.T) {
+	tests := []struct {
+		name       string
+		courseText string
+		wantMarker string
+		wantStart  int
+	}{
+		{"Turkish row", "Statics Tr 3 0 3 5 BB 3.00", "Tr", 8},
+		{"English row", "Statics İng. 3 0 3 5 BB 3.00", "İng.", 8},
+		{"Tr inside the name", "Transportation Planning Tr 3 0 3 5 BB 3.00", "Tr", 24},
+		{"İng inside the name", "İngilizce II İng. 3 0 3 4 AA 4.00", "İng.", 14}, // byte offset; İ is two bytes
+		{"name only", "Transportation Planning", "", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marker, start, _ := findLanguageMarker(tt.courseText, defaultProfile())
+			if marker != tt.wantMarker || start != tt.wantStart {
+				t.Errorf("findLanguageMarker(%q) = %q at %d, want %q at %d", tt.courseText, marker, start, tt.wantMarker, tt.wantStart)
+			}
+		})
+	}
+}
+
+func TestParseTranscriptTextLanguageMarkerInName(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      string
+		wantCode string
+		wantName string
+	}{
+		{"Tr-containing name", "ULS 101 Transportation Planning Tr 3 0 3 5 BB 3.00", "ULS 101", "Transportation Planning"},
+		{"Tr-containing English course", "ULS 201E Traffic Engineering İng. 3 0 3 5 CB 2.50", "ULS 201E", "Traffic Engineering"},
+		{"İng-containing name", "ING 102 İngilizce II Tr 3 0 3 4 AA 4.00", "ING 102", "İngilizce II"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			courses, _, err := parseTranscriptText("2022-2023 Güz Dönemi\n"+tt.row+"\n", defaultProfile())
+			if err != nil {
+				t.Fatalf("parseTranscriptText: %v", err)
+			}
+			if len(courses) != 1 {
+				t.Fatalf("parsed %d courses, want 1: %+v", len(courses), courses)
+			}
+			if courses[0].Code != tt.wantCode || courses[0].Name != tt.wantName || courses[0].Credits != "3" {
+				t.Errorf("parsed %q %q credits %q, want %q %q credits 3",
+					courses[0].Code, courses[0].Name, courses[0].Credits, tt.wantCode, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestFindGrade(t *testing.T) {
+	tests := []struct {
+		name       string
+		courseText string
+		wantGrade  string
+		wantStart  int
+	}{
+		{"plus grade", "Statics BA+ 3.75", "BA+", 8},
+		{"plus grade at the end", "Statics BA+", "BA+", 8},
+		{"every plus grade", "Statics DC+ 1.75", "DC+", 8},
+		{"base grade", "Statics BA 3.50", "BA", 8},
+		{"grade inside a word", "DATABASE Systems", "", -1},
+		{"grade after a word containing one", "DATABASE Systems CB+ 2.75", "CB+", 17},
+		{"no grade", "Statics 3 0 3", "", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grade, start := findGrade(tt.courseText, defaultProfile())
+			if grade != tt.wantGrade || start != tt.wantStart {
+				t.Errorf("findGrade(%q) = %q at %d, want %q at %d", tt.courseText, grade, start, tt.wantGrade, tt.wantStart)
+			}
+		})
+	}
+}
+
+func TestParseTranscriptTextPlusGrades(t *testing.T) {
+	tests := []struct {
+		name       string
+		row        string
+		wantSource string
+	}{
+		{"full row", "BLG 101E Introduction to Computing İng. 3 0 3 5 BA+ 3.75", "complexPattern"},
+		{"blank UK column", "BLG 101E Introduction to Computing İng. 3 0 5 BA+", "ectsOnly"},
+		{"columns run together", "BLG 101E Introduction to Computing İng.3035 BA+", "simplePattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			courses, _, err := parseTranscriptText("2022-2023 Güz Dönemi\n"+tt.row+"\n", defaultProfile())
+			if err != nil {
+				t.Fatalf("parseTranscriptText: %v", err)
+			}
+			if len(courses) != 1 || courses[0].Grade != "BA+" || courses[0].ParseSource != tt.wantSource {
+				t.Errorf("parsed %+v, want one course graded BA+ by %s", courses, tt.wantSource)
+			}
+		})
+	}
+
+	for _, plus := range []string{"BA+", "BB+", "CB+", "CC+", "DC+", "DD+"} {
+		t.Run(plus, func(t *testing.T) {
+			row := "BLG 101E Introduction to Computing İng. 3 0 3 5 " + plus + " 1.00"
+			courses, _, err := parseTranscriptText("2022-2023 Güz Dönemi\n"+row+"\n", defaultProfile())
+			if err != nil {
+				t.Fatalf("parseTranscriptText: %v", err)
+			}
+			if len(courses) != 1 || courses[0].Grade != plus {
+				t.Errorf("parsed %+v, want one course graded %s", courses, plus)
+			}
+		})
+	}
+}
+
+func TestReadInBackground(t *testing.T) {
+	tests := []struct {
+		name     string
+		read     func() (pdfText, error)
+		wantText string
+		wantErr  string
+	}{
+		{"text", func() (pdfText, error) { return pdfText{text: "BLG 101E", pages: 1}, nil }, "BLG 101E", ""},
+		{"error", func() (pdfText, error) { return pdfText{}, errors.New("failed to create PDF reader") }, "", "failed to create PDF reader"},
+		{"panic", func() (pdfText, error) { panic("malformed PDF: invalid page tree") }, "", "pdf: malformed PDF: invalid page tree"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extracted, err := readInBackground(context.Background(), tt.read)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || extracted.text != tt.wantText {
+				t.Errorf("readInBackground() = %+v, %v; want text %q", extracted, err, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestReadPagesCanceledMidParse(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	read := 0
+	_, err := readPages(ctx, 3, func(page int) (string, error) {
+		read++
+		if page == 1 {
+			cancel()
+		}
+		return "BLG 101E", nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if read != 1 {
+		t.Errorf("read %d pages, want to stop after page 1", read)
+	}
+}
+
+func TestWarningsOnly(t *testing.T) {
+	warning := ParseWarning{Kind: WarningDuplicateRemoved, Message: "duplicate removed"}
+	tests := []struct {
+		name string
+		resp *ParseTranscriptResponse
+		want *ParseTranscriptResponse
+	}{
+		{
+			"successful parse",
+			&ParseTranscriptResponse{
+				Courses:       []TranscriptCourse{{Code: "MAT 103E", Grade: "BB"}},
+				Program:       "Computer Engineering",
+				StudentNumber: "150200001",
+				Diagnostics:   &ParseDiagnostics{Warnings: []ParseWarning{warning}},
+				ReviewWarning: "please review",
+			},
+			&ParseTranscriptResponse{
+				Diagnostics:   &ParseDiagnostics{Warnings: []ParseWarning{warning}},
+				ReviewWarning: "please review",
+			},
+		},
+		{
+			"failed parse",
+			&ParseTranscriptResponse{
+				Error:       "No courses found in transcript",
+				Diagnostics: &ParseDiagnostics{NoCourses: &NoCoursesReport{Reason: "no semesters"}},
+			},
+			&ParseTranscriptResponse{
+				Error:       "No courses found in transcript",
+				Diagnostics: &ParseDiagnostics{NoCourses: &NoCoursesReport{Reason: "no semesters"}},
+			},
+		},
+		{
+			"failure without diagnostics",
+			&ParseTranscriptResponse{Error: "Failed to decode base64 PDF"},
+			&ParseTranscriptResponse{Error: "Failed to decode base64 PDF", Diagnostics: &ParseDiagnostics{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := warningsOnly(tt.resp); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("warningsOnly() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTranscriptWarningsOnlyInvalidPDF(t *testing.T) {
+	resp, err := ParseTranscript(context.Background(), &ParseTranscriptRequest{
+		PDFBase64:    "not base64!",
+		WarningsOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("ParseTranscript() error = %v", err)
+	}
+	if resp.Error == "" || resp.Diagnostics == nil {
+		t.Errorf("ParseTranscript() = %+v, want an error and diagnostics", resp)
+	}
+}
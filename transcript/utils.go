@@ -2,8 +2,14 @@ package transcript
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"strings"
+
+	"encore.app/grades"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 )
 
 // LoadCoursesFromJSONFile loads courses from a JSON file and converts them to Course structs
@@ -33,9 +39,32 @@ func ConvertRawJSONToCourses(data []byte) ([]Course, error) {
 	return courses, nil
 }
 
+// toCourses converts parsed transcript courses to Course structs for storage
+func toCourses(parsed []TranscriptCourse) []Course {
+	courses := []Course{}
+	for _, tc := range parsed {
+		courses = append(courses, Course{
+			Semester: tc.Semester,
+			Code:     tc.Code,
+			Name:     tc.Name,
+			Credits:  tc.Credits,
+			Grade:    tc.Grade,
+			LessonID: tc.LessonID,
+			ECTS:     tc.ECTS,
+			Points:   tc.Points,
+
+			CreditsFromECTS: tc.CreditsFromECTS,
+			InProgress:      tc.InProgress,
+			Exchange:        tc.Exchange,
+			Language:        tc.Language,
+		})
+	}
+	return courses
+}
+
 // GetCoursesBySemester filters courses by semester
 func GetCoursesBySemester(courses []Course, semester string) []Course {
-	var filtered []Course
+	filtered := []Course{}
 	for _, course := range courses {
 		if course.Semester == semester {
 			filtered = append(filtered, course)
@@ -44,38 +73,309 @@ func GetCoursesBySemester(courses []Course, semester string) []Course {
 	return filtered
 }
 
-// GetCoursesByGrade filters courses by grade
-func GetCoursesByGrade(courses []Course, grade string) []Course {
-	var filtered []Course
+// GetCoursesByGrade filters courses by one or more grades
+func GetCoursesByGrade(courses []Course, grades ...string) []Course {
+	wanted := make(map[string]bool, len(grades))
+	for _, grade := range grades {
+		wanted[grade] = true
+	}
+
+	filtered := []Course{}
 	for _, course := range courses {
-		if course.Grade == grade {
+		if wanted[course.Grade] {
 			filtered = append(filtered, course)
 		}
 	}
 	return filtered
 }
 
-// CalculateGPASummary calculates GPA and credit summary from courses
-func CalculateGPASummary(courses []Course) (float64, float64, int) {
+// SearchCoursesByName returns the courses whose name contains query, ignoring
+// case and the dotted/dotless i distinction, see foldSearch
+func SearchCoursesByName(courses []Course, query string) []Course {
+	folded := foldSearch(query)
+	matches := []Course{}
+	for _, course := range courses {
+		if strings.Contains(foldSearch(course.Name), folded) {
+			matches = append(matches, course)
+		}
+	}
+	return matches
+}
+
+// knownGrades lists every grade string the parser can produce: the letter grades
+// with their plus variants, pass/fail markers and "--" for an ungraded row. The
+// parser's gradeAlternation and the letter grades of gradeScales must stay in
+// step with this list. In-progress and withdrawal markers are configured
+// separately by the grades package.
+var knownGrades = []string{
+	"AA", "BA+", "BA", "BB+", "BB", "CB+", "CB", "CC+", "CC",
+	"DC+", "DC", "DD+", "DD", "FF", "VF", "BL", "SG", "DK", "KL", "--",
+	"G", "K", "S", "U",
+}
+
+// passFailGrades maps the pass/fail markers of non-GPA courses to whether they
+// pass: BL (Başarılı), G (Geçer) and S (Satisfactory) pass, K (Kalır) and
+// U (Unsatisfactory) fail. They earn credits when passed but never affect the
+// GPA, so none of them may appear in gradeScales.
+var passFailGrades = map[string]bool{
+	"BL": true, "G": true, "S": true,
+	"K": false, "U": false,
+}
+
+// nonEarningGrades lists grades that don't earn a course's credits
+var nonEarningGrades = map[string]bool{
+	"FF": true, "VF": true, "--": true, "": true,
+	"K": true, "U": true,
+}
+
+// isEarnedCourse reports whether a course was completed and earns its credits
+// and ECTS: it isn't failed, in progress or withdrawn, nor a failed exchange course
+func isEarnedCourse(course Course) bool {
+	if nonEarningGrades[course.Grade] || grades.IsInProgress(course.Grade) || grades.IsWithdrawn(course.Grade) {
+		return false
+	}
+	return !course.Exchange || !grades.IsExchangeFailure(course.Grade)
+}
+
+// CalculateEarnedCredits sums the credits of completed courses, including
+// pass/fail courses that were passed
+func CalculateEarnedCredits(courses []Course) float64 {
+	earned := 0.0
+	for _, course := range courses {
+		if !isEarnedCourse(course) {
+			continue
+		}
+		if credits, err := parseFloat(course.Credits); err == nil {
+			earned += credits
+		}
+	}
+	return earned
+}
+
+// CalculatePassFailSummary totals the pass/fail graded courses, which
+// CalculateGPASummary leaves out
+func CalculatePassFailSummary(courses []Course) PassFailSummary {
+	var summary PassFailSummary
+	for _, course := range courses {
+		passed, ok := passFailGrades[course.Grade]
+		if !ok {
+			continue
+		}
+		if !passed {
+			summary.FailedCount++
+			continue
+		}
+		summary.PassedCount++
+		if credits, err := parseFloat(course.Credits); err == nil {
+			summary.EarnedCredits += credits
+		}
+	}
+	return summary
+}
+
+// isKnownGrade reports whether grade is one of the known grade strings, an
+// in-progress marker or a withdrawal marker
+func isKnownGrade(grade string) bool {
+	for _, known := range knownGrades {
+		if grade == known {
+			return true
+		}
+	}
+	return grades.IsInProgress(grade) || grades.IsWithdrawn(grade)
+}
+
+// normalizeGrade canonicalizes a user-supplied grade. Letter grades are upper
+// case, but markers such as "Devam" and "NG" are matched case-insensitively and
+// returned as configured, since upper-casing would turn "devam" into "DEVAM".
+func normalizeGrade(grade string) string {
+	grade = strings.TrimSpace(grade)
+	for _, marker := range grades.Markers() {
+		if strings.EqualFold(grade, marker) {
+			return marker
+		}
+	}
+	return strings.ToUpper(grade)
+}
+
+// hasUnknownGrade reports whether a course's grade is one the GPA utilities
+// don't recognize. Exchange courses carry foreign grades and never count.
+func hasUnknownGrade(course Course) bool {
+	return !course.Exchange && !isKnownGrade(course.Grade)
+}
+
+// UnknownGradeCourses returns the courses whose grade isn't recognized and is
+// therefore left out of the GPA
+func UnknownGradeCourses(courses []Course) []Course {
+	unknown := []Course{}
+	for _, course := range courses {
+		if hasUnknownGrade(course) {
+			unknown = append(unknown, course)
+		}
+	}
+	return unknown
+}
+
+// CalculateAttemptedCredits sums the credits of every course with a final
+// outcome: passed, failed or withdrawn. Only in-progress courses are left out.
+func CalculateAttemptedCredits(courses []Course) float64 {
+	attempted := 0.0
+	for _, course := range courses {
+		if course.InProgress || grades.IsInProgress(course.Grade) {
+			continue
+		}
+		if credits, err := parseFloat(course.Credits); err == nil {
+			attempted += credits
+		}
+	}
+	return attempted
+}
+
+// WithdrawnCourses returns the courses the student withdrew from
+func WithdrawnCourses(courses []Course) []Course {
+	withdrawn := []Course{}
+	for _, course := range courses {
+		if grades.IsWithdrawn(course.Grade) {
+			withdrawn = append(withdrawn, course)
+		}
+	}
+	return withdrawn
+}
+
+// gradeScales holds the named grade-to-point tables GPAs can be computed with.
+// Every letter grade in knownGrades, plus variants included, must have points
+// in each scale or its courses silently drop out of the GPA. Only letter grades
+// carry quality points; pass/fail grades are left out.
+var gradeScales = map[string]map[string]float64{
+	// ITU's official 4.00 scale; a plus grade sits a quarter point above its base
+	"itu": {
+		"AA": 4.0, "BA+": 3.75, "BA": 3.5, "BB+": 3.25, "BB": 3.0,
+		"CB+": 2.75, "CB": 2.5, "CC+": 2.25, "CC": 2.0, "DC+": 1.75,
+		"DC": 1.5, "DD+": 1.25, "DD": 1.0, "FD": 0.5,
+		"FF": 0.0, "VF": 0.0,
+	},
+	// ITU letter grades mapped onto the common US 4.0 bands; a plus grade sits
+	// halfway between its base and the next higher grade
+	"us4": {
+		"AA": 4.0, "BA+": 3.85, "BA": 3.7, "BB+": 3.5, "BB": 3.3,
+		"CB+": 3.15, "CB": 3.0, "CC+": 2.85, "CC": 2.7, "DC+": 2.5,
+		"DC": 2.3, "DD+": 2.15, "DD": 2.0, "FD": 1.0,
+		"FF": 0.0, "VF": 0.0,
+	},
+}
+
+// GPASummary is the GPA and credit totals of a list of courses
+type GPASummary struct {
+	GPA float64 `json:"gpa"`
+	// TotalCredits counts only the credits of courses whose grade affects the GPA
+	TotalCredits float64 `json:"totalCredits"`
+	// EarnedCredits counts every earned credit, including passed pass/fail courses
+	EarnedCredits float64 `json:"earnedCredits"`
+	// AttemptedCredits also counts failed and withdrawn courses
+	AttemptedCredits float64 `json:"attemptedCredits"`
+	// CourseCount is the number of courses that affect the GPA
+	CourseCount int `json:"courseCount"`
+	// Unparsed counts courses left out because their credits or grade couldn't be read
+	Unparsed int `json:"unparsed,omitempty"`
+}
+
+// CalculateGPASummary calculates GPA and credit summary from courses using the
+// default grade scale
+func CalculateGPASummary(courses []Course) GPASummary {
+	return CalculateGPASummaryWithScale(courses, nil)
+}
+
+// CalculateGPASummaryWithScale calculates GPA and credit summary using scale to
+// override the points of some grades, e.g. an older faculty table. Grades the
+// override doesn't list keep their points from the default grade scale.
+func CalculateGPASummaryWithScale(courses []Course, scale map[string]float64) GPASummary {
+	defaults := gradeScales[defaultProfile().GradeScale]
+	if len(scale) == 0 {
+		return calculateGPASummary(courses, defaults)
+	}
+
+	gradePoints := make(map[string]float64, len(defaults)+len(scale))
+	for grade, points := range defaults {
+		gradePoints[grade] = points
+	}
+	for grade, points := range scale {
+		gradePoints[grade] = points
+	}
+	return calculateGPASummary(courses, gradePoints)
+}
+
+// CalculateGPASummaryExcluding calculates the GPA summary over the courses for
+// which exclude returns false
+func CalculateGPASummaryExcluding(courses []Course, exclude func(Course) bool) GPASummary {
+	var included []Course
+	for _, course := range courses {
+		if !exclude(course) {
+			included = append(included, course)
+		}
+	}
+	return CalculateGPASummary(included)
+}
+
+// SemesterSummary is the GPA summary (DNO) of one semester
+type SemesterSummary struct {
+	Semester string `json:"semester"`
+	GPASummary
+}
+
+// CalculateSemesterGPAs calculates the GPA summary of each semester in
+// chronological order, labelled exactly as parsed. Summer school terms are
+// summarized like any other semester, and grades the cumulative GPA ignores are
+// ignored here too.
+func CalculateSemesterGPAs(courses []Course) []SemesterSummary {
+	return calculateSemesterGPAs(courses, gradeScales[defaultProfile().GradeScale])
+}
+
+// calculateSemesterGPAs calculates the GPA summary of each semester using the
+// given grade points
+func calculateSemesterGPAs(courses []Course, gradePoints map[string]float64) []SemesterSummary {
+	summaries := []SemesterSummary{}
+	for _, semester := range semestersOf(courses) {
+		summaries = append(summaries, SemesterSummary{
+			Semester:   semester,
+			GPASummary: calculateGPASummary(GetCoursesBySemester(courses, semester), gradePoints),
+		})
+	}
+	return summaries
+}
+
+// calculateGPASummary calculates GPA and credit summary using the given grade points
+func calculateGPASummary(courses []Course, gradePoints map[string]float64) GPASummary {
 	totalPoints := 0.0
 	totalCredits := 0.0
 	courseCount := 0
-
-	gradePoints := map[string]float64{
-		"AA": 4.0, "BA": 3.5, "BB": 3.0, "CB": 2.5,
-		"CC": 2.0, "DC": 1.5, "DD": 1.0, "FD": 0.5,
-		"FF": 0.0, "VF": 0.0, "BL": 0.0,
-	}
+	unparsed := 0
 
 	for _, course := range courses {
+		if course.Exchange {
+			continue // Foreign grades have no ITU coefficient
+		}
+
 		credits, err := parseFloat(course.Credits)
 		if err != nil {
-			continue // Skip courses with invalid credits
+			unparsed++ // Skip courses with invalid credits
+			continue
+		}
+
+		if course.InProgress || grades.IsInProgress(course.Grade) {
+			continue // Skip courses that have no grade yet
+		}
+		if _, passFail := passFailGrades[course.Grade]; passFail {
+			continue // Pass/fail credits are earned but carry no quality points
+		}
+		if grades.IsWithdrawn(course.Grade) {
+			continue // Withdrawn courses neither pass nor fail
 		}
 
 		points, exists := gradePoints[course.Grade]
 		if !exists {
-			continue // Skip courses with unknown grades
+			if !isKnownGrade(course.Grade) {
+				unparsed++ // Pass/fail grades are known but never in the scale
+			}
+			continue
 		}
 
 		totalPoints += points * credits
@@ -83,16 +383,48 @@ func CalculateGPASummary(courses []Course) (float64, float64, int) {
 		courseCount++
 	}
 
-	var gpa float64
+	summary := GPASummary{
+		TotalCredits:     totalCredits,
+		EarnedCredits:    CalculateEarnedCredits(courses),
+		AttemptedCredits: CalculateAttemptedCredits(courses),
+		CourseCount:      courseCount,
+		Unparsed:         unparsed,
+	}
 	if totalCredits > 0 {
-		gpa = totalPoints / totalCredits
+		summary.GPA = totalPoints / totalCredits
 	}
 
-	return gpa, totalCredits, courseCount
+	return summary
+}
+
+// foldTurkish lowercases s using Turkish casing rules, so that "İ" folds to "i"
+// and "I" to "ı" instead of being mangled by strings.ToLower
+func foldTurkish(s string) string {
+	return cases.Lower(language.Turkish).String(s)
 }
 
+// foldSearch folds s for name search. Turkish lowering turns the "I" of English
+// names like "Introduction" into "ı", so "ı" is further folded to "i" and an
+// ASCII query matches names in either language.
+func foldSearch(s string) string {
+	return strings.ReplaceAll(foldTurkish(s), "ı", "i")
+}
+
+// isLabCourse reports whether a course name denotes a laboratory course
+func isLabCourse(name string) bool {
+	folded := foldTurkish(name)
+	return strings.Contains(folded, "laboratory") || strings.Contains(folded, "lab")
+}
+
+// errEmptyNumber is returned by parseFloat for blank values, e.g. the credits of
+// courses the generic fallback parser couldn't read
+var errEmptyNumber = errors.New("empty number")
+
 // Helper function to parse string to float
 func parseFloat(s string) (float64, error) {
+	if strings.TrimSpace(s) == "" {
+		return 0, errEmptyNumber
+	}
 	var f float64
 	_, err := fmt.Sscanf(s, "%f", &f)
 	return f, err
@@ -0,0 +1,550 @@
+package transcript
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// approxEqual compares GPAs and credit totals, which are sums of floats
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCalculateGPASummaryCredits(t *testing.T) {
+	tests := []struct {
+		name          string
+		courses       []Course
+		gpa           float64
+		totalCredits  float64
+		earnedCredits float64
+		courseCount   int
+	}{
+		{
+			name: "graded courses only",
+			courses: []Course{
+				{Code: "MAT 103E", Credits: "4", Grade: "AA"},
+				{Code: "FIZ 101E", Credits: "4", Grade: "CC"},
+			},
+			gpa:           3.0,
+			totalCredits:  8,
+			earnedCredits: 8,
+			courseCount:   2,
+		},
+		{
+			name: "graded, pass/fail and exempt courses",
+			courses: []Course{
+				{Code: "MAT 103E", Credits: "3", Grade: "AA"},
+				{Code: "FIZ 101E", Credits: "4", Grade: "BB"},
+				{Code: "KIM 101E", Credits: "3", Grade: "FF"},
+				{Code: "ING 100", Credits: "2", Grade: "BL"},
+				{Code: "BIO 101E", Credits: "2", Grade: "K"},
+				{Code: "TUR 101", Credits: "3", Grade: "DK"},
+			},
+			gpa:           2.4,
+			totalCredits:  10,
+			earnedCredits: 12,
+			courseCount:   3,
+		},
+		{
+			name: "pass/fail courses only",
+			courses: []Course{
+				{Code: "ING 100", Credits: "2", Grade: "BL"},
+				{Code: "ING 101", Credits: "2", Grade: "G"},
+			},
+			gpa:           0,
+			totalCredits:  0,
+			earnedCredits: 4,
+			courseCount:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := CalculateGPASummary(tt.courses)
+			if !approxEqual(summary.GPA, tt.gpa) {
+				t.Errorf("GPA = %v, want %v", summary.GPA, tt.gpa)
+			}
+			if !approxEqual(summary.TotalCredits, tt.totalCredits) {
+				t.Errorf("TotalCredits = %v, want %v", summary.TotalCredits, tt.totalCredits)
+			}
+			if !approxEqual(summary.EarnedCredits, tt.earnedCredits) {
+				t.Errorf("EarnedCredits = %v, want %v", summary.EarnedCredits, tt.earnedCredits)
+			}
+			if summary.CourseCount != tt.courseCount {
+				t.Errorf("CourseCount = %d, want %d", summary.CourseCount, tt.courseCount)
+			}
+		})
+	}
+}
+
+func TestGPASummaryJSONKeepsTotalCredits(t *testing.T) {
+	summary := CalculateGPASummary([]Course{
+		{Code: "MAT 103E", Credits: "4", Grade: "AA"},
+		{Code: "ING 100", Credits: "2", Grade: "BL"},
+	})
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]float64
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	// totalCredits has always meant the credits counted in the GPA
+	if got := fields["totalCredits"]; got != 4 {
+		t.Errorf("totalCredits = %v, want the GPA credits 4", got)
+	}
+	if got := fields["earnedCredits"]; got != 6 {
+		t.Errorf("earnedCredits = %v, want 6", got)
+	}
+}
+
+func TestInProgressCoursesExcludedFromGPA(t *testing.T) {
+	graded := []Course{
+		{Semester: "2022-2023 Güz Dönemi", Code: "MAT 103E", Credits: "4", Grade: "BA"},
+	}
+	for _, marker := range []string{"--", "", "Devam", "NG"} {
+		t.Run(marker, func(t *testing.T) {
+			courses := append([]Course{
+				{Semester: "2022-2023 Bahar Dönemi", Code: "MAT 104E", Credits: "4", Grade: marker},
+			}, graded...)
+
+			summary := CalculateGPASummary(courses)
+			if summary.GPA != 3.5 || summary.TotalCredits != 4 || summary.CourseCount != 1 {
+				t.Errorf("summary = %+v, want only the graded course counted", summary)
+			}
+			if summary.EarnedCredits != 4 {
+				t.Errorf("earned credits = %v, want 4", summary.EarnedCredits)
+			}
+			if summary.Unparsed != 0 {
+				t.Errorf("unparsed = %d, want the in-progress course recognized", summary.Unparsed)
+			}
+			if got := GetCoursesBySemester(courses, "2022-2023 Bahar Dönemi"); len(got) != 1 {
+				t.Errorf("in-progress course not listed: %+v", got)
+			}
+		})
+	}
+}
+
+func TestWithdrawnCourseExcludedFromGPAAndEarnedCredits(t *testing.T) {
+	graded := []Course{
+		{Semester: "2022-2023 Güz Dönemi", Code: "MAT 103E", Credits: "4", Grade: "BB"},
+	}
+	withdrawn := append([]Course{
+		{Semester: "2022-2023 Güz Dönemi", Code: "FIZ 101E", Credits: "3", Grade: "W"},
+	}, graded...)
+
+	before := CalculateGPASummary(graded)
+	after := CalculateGPASummary(withdrawn)
+	if after.GPA != before.GPA || after.TotalCredits != before.TotalCredits {
+		t.Errorf("withdrawal changed the GPA: %+v, want %+v", after, before)
+	}
+	if after.EarnedCredits != before.EarnedCredits {
+		t.Errorf("earned credits = %v, want %v", after.EarnedCredits, before.EarnedCredits)
+	}
+	if after.AttemptedCredits != 7 {
+		t.Errorf("attempted credits = %v, want 7 including the withdrawn course", after.AttemptedCredits)
+	}
+	if got := WithdrawnCourses(withdrawn); len(got) != 1 || got[0].Code != "FIZ 101E" {
+		t.Errorf("WithdrawnCourses = %+v, want the withdrawn course", got)
+	}
+}
+
+func TestCalculateSemesterGPAs(t *testing.T) {
+	tests := []struct {
+		name    string
+		courses []Course
+		want    []SemesterSummary
+	}{
+		{
+			name:    "no courses",
+			courses: nil,
+			want:    []SemesterSummary{},
+		},
+		{
+			name: "chronological order regardless of course order",
+			courses: []Course{
+				{Semester: "2022-2023 Bahar Dönemi", Code: "MAT 104E", Credits: "4", Grade: "BB"},
+				{Semester: "2022-2023 Yaz Okulu", Code: "FIZ 102E", Credits: "3", Grade: "CC"},
+				{Semester: "2022-2023 Güz Dönemi", Code: "MAT 103E", Credits: "4", Grade: "AA"},
+			},
+			want: []SemesterSummary{
+				{Semester: "2022-2023 Güz Dönemi", GPASummary: GPASummary{GPA: 4, TotalCredits: 4, EarnedCredits: 4, AttemptedCredits: 4, CourseCount: 1}},
+				{Semester: "2022-2023 Bahar Dönemi", GPASummary: GPASummary{GPA: 3, TotalCredits: 4, EarnedCredits: 4, AttemptedCredits: 4, CourseCount: 1}},
+				{Semester: "2022-2023 Yaz Okulu", GPASummary: GPASummary{GPA: 2, TotalCredits: 3, EarnedCredits: 3, AttemptedCredits: 3, CourseCount: 1}},
+			},
+		},
+		{
+			name: "unknown grade left out of the semester",
+			courses: []Course{
+				{Semester: "2022-2023 Güz Dönemi", Code: "MAT 103E", Credits: "4", Grade: "AA"},
+				{Semester: "2022-2023 Güz Dönemi", Code: "FIZ 101E", Credits: "3", Grade: "QQ"},
+			},
+			want: []SemesterSummary{
+				{Semester: "2022-2023 Güz Dönemi", GPASummary: GPASummary{GPA: 4, TotalCredits: 4, EarnedCredits: 7, AttemptedCredits: 7, CourseCount: 1, Unparsed: 1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateSemesterGPAs(tt.courses)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d semesters, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("semester %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFoldTurkish(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"İSTANBUL", "istanbul"},
+		{"ISI", "ısı"},
+		{"FİZİK LABORATUVARI", "fizik laboratuvarı"},
+		{"Bilgisayar", "bilgisayar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := foldTurkish(tt.in); got != tt.want {
+				t.Errorf("foldTurkish(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchCoursesByName(t *testing.T) {
+	courses := []Course{
+		{Code: "BLG 101E", Name: "Introduction to Computing"},
+		{Code: "FIZ 101E", Name: "FİZİK I"},
+		{Code: "MUK 201", Name: "ISI TRANSFERİ"},
+		{Code: "MAT 103E", Name: "Calculus I"},
+	}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"intro", []string{"BLG 101E"}},
+		{"INTRO", []string{"BLG 101E"}},
+		{"fizik", []string{"FIZ 101E"}},
+		{"ısı", []string{"MUK 201"}},
+		{"isi", []string{"MUK 201"}},
+		{"transferi", []string{"MUK 201"}},
+		{"calculus i", []string{"MAT 103E"}},
+		{"physics", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := []string{}
+			for _, course := range SearchCoursesByName(courses, tt.query) {
+				got = append(got, course.Code)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchCoursesByName(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLabCourse(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Physics Laboratory", true},
+		{"FİZİK LABORATUVARI", true},
+		{"KİMYA LAB.", true},
+		{"Elektrik Devreleri Lab", true},
+		{"İnşaat Mühendisliğine Giriş", false},
+		{"IŞIK VE OPTİK", false},
+		{"Calculus", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isLabCourse(tt.name); got != tt.want {
+				t.Errorf("isLabCourse(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculatePassFailSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		courses []Course
+		want    PassFailSummary
+	}{
+		{
+			name: "graded courses only",
+			courses: []Course{
+				{Code: "MAT 103E", Credits: "4", Grade: "AA"},
+			},
+			want: PassFailSummary{},
+		},
+		{
+			name: "passed and failed",
+			courses: []Course{
+				{Code: "MAT 103E", Credits: "4", Grade: "AA"},
+				{Code: "ING 101", Credits: "2", Grade: "G"},
+				{Code: "ING 100", Credits: "2", Grade: "BL"},
+				{Code: "STJ 200", Credits: "1", Grade: "S"},
+				{Code: "BIO 101E", Credits: "2", Grade: "K"},
+				{Code: "STJ 300", Credits: "1", Grade: "U"},
+			},
+			want: PassFailSummary{EarnedCredits: 5, PassedCount: 3, FailedCount: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculatePassFailSummary(tt.courses); got != tt.want {
+				t.Errorf("CalculatePassFailSummary() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmptyListsSerializeAsArrays(t *testing.T) {
+	courses := []Course{{Semester: "2022-2023 Güz Dönemi", Code: "MAT 103E", Credits: "4", Grade: "AA"}}
+
+	tests := []struct {
+		name string
+		list any
+	}{
+		{"toCourses without parsed courses", toCourses(nil)},
+		{"GetCoursesBySemester without a match", GetCoursesBySemester(courses, "2023-2024 Güz Dönemi")},
+		{"GetCoursesByGrade without a match", GetCoursesByGrade(courses, "FF")},
+		{"GetCoursesBySemester of no courses", GetCoursesBySemester(nil, "2022-2023 Güz Dönemi")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.list)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != "[]" {
+				t.Errorf("serialized as %s, want []", data)
+			}
+		})
+	}
+}
+
+func TestPassCourseDoesNotLowerGPA(t *testing.T) {
+	graded := []Course{
+		{Code: "MAT 103E", Credits: "4", Grade: "AA"},
+		{Code: "FIZ 101E", Credits: "4", Grade: "BA"},
+	}
+	before := CalculateGPASummary(graded)
+
+	for _, grade := range []string{"BL", "G", "S"} {
+		t.Run(grade, func(t *testing.T) {
+			courses := append([]Course{{Code: "ING 100", Credits: "3", Grade: grade}}, graded...)
+			after := CalculateGPASummary(courses)
+			if !approxEqual(after.GPA, before.GPA) || after.TotalCredits != before.TotalCredits || after.CourseCount != before.CourseCount {
+				t.Errorf("pass grade changed the GPA: %+v, want %+v", after, before)
+			}
+			if !approxEqual(after.EarnedCredits, before.EarnedCredits+3) {
+				t.Errorf("earned credits = %v, want %v", after.EarnedCredits, before.EarnedCredits+3)
+			}
+		})
+	}
+}
+
+func TestGradeScalesExcludePassFailGrades(t *testing.T) {
+	for name, scale := range gradeScales {
+		for grade := range passFailGrades {
+			if _, ok := scale[grade]; ok {
+				t.Errorf("grade scale %q gives pass/fail grade %s quality points", name, grade)
+			}
+		}
+	}
+}
+
+func TestUnknownGradeCourses(t *testing.T) {
+	made := Course{Semester: "2022-2023 Güz Dönemi", Code: "BLG 101E", Credits: "3", Grade: "XZ"}
+	courses := []Course{
+		{Semester: "2022-2023 Güz Dönemi", Code: "MAT 103E", Credits: "4", Grade: "BA+"},
+		{Semester: "2022-2023 Güz Dönemi", Code: "ING 100", Credits: "2", Grade: "BL"},
+		{Semester: "2022-2023 Bahar Dönemi", Code: "MAT 104E", Credits: "4", Grade: "--"},
+		{Semester: "2022-2023 Bahar Dönemi", Code: "ERA 101", Credits: "5", Grade: "XZ", Exchange: true},
+		made,
+	}
+
+	tests := []struct {
+		name    string
+		courses []Course
+		want    []Course
+	}{
+		{"made-up grade", courses, []Course{made}},
+		{"known grades only", courses[:3], []Course{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnknownGradeCourses(tt.courses); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnknownGradeCourses() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	summary := CalculateGPASummary(courses)
+	if summary.CourseCount != 1 || summary.Unparsed != 1 {
+		t.Errorf("summary = %+v, want the made-up grade left out of the GPA and counted as unparsed", summary)
+	}
+}
+
+func TestGradeScalesPlusGrades(t *testing.T) {
+	// each plus grade sits between its base grade and the next higher grade
+	plusGrades := []struct {
+		plus, base, above string
+	}{
+		{"BA+", "BA", "AA"},
+		{"BB+", "BB", "BA"},
+		{"CB+", "CB", "BB"},
+		{"CC+", "CC", "CB"},
+		{"DC+", "DC", "CC"},
+		{"DD+", "DD", "DC"},
+	}
+	for name, scale := range gradeScales {
+		for _, g := range plusGrades {
+			t.Run(name+" "+g.plus, func(t *testing.T) {
+				points, ok := scale[g.plus]
+				if !ok {
+					t.Fatalf("scale %q has no points for %s", name, g.plus)
+				}
+				if points <= scale[g.base] || points >= scale[g.above] {
+					t.Errorf("%s = %v, want between %s (%v) and %s (%v)",
+						g.plus, points, g.base, scale[g.base], g.above, scale[g.above])
+				}
+			})
+		}
+	}
+}
+
+func TestPlusGradesContributeToGPA(t *testing.T) {
+	courses := []Course{
+		{Code: "MAT 103E", Credits: "4", Grade: "BA+"},
+		{Code: "FIZ 101E", Credits: "4", Grade: "BB"},
+	}
+
+	tests := []struct {
+		scale string
+		gpa   float64
+	}{
+		{"itu", (3.75 + 3.0) / 2},
+		{"us4", (3.85 + 3.3) / 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scale, func(t *testing.T) {
+			summary := calculateGPASummary(courses, gradeScales[tt.scale])
+			if !approxEqual(summary.GPA, tt.gpa) || summary.CourseCount != 2 || summary.TotalCredits != 8 {
+				t.Errorf("summary = %+v, want GPA %v over both courses", summary, tt.gpa)
+			}
+		})
+	}
+}
+
+func TestParserGradesAreKnown(t *testing.T) {
+	for _, grade := range strings.Split(strings.ReplaceAll(gradeAlternation, `\+`, "+"), "|") {
+		if !isKnownGrade(grade) {
+			t.Errorf("parser grade %q is not in knownGrades", grade)
+		}
+	}
+}
+
+func TestCalculateGPASummaryWithScale(t *testing.T) {
+	courses := []Course{
+		{Code: "MAT 103E", Credits: "4", Grade: "AA"},
+		{Code: "FIZ 101E", Credits: "4", Grade: "CC"},
+		{Code: "ING 100", Credits: "2", Grade: "BL"},
+	}
+
+	tests := []struct {
+		name  string
+		scale map[string]float64
+		gpa   float64
+	}{
+		{"no override", nil, 3.0},
+		{"empty override", map[string]float64{}, 3.0},
+		{"override a graded course", map[string]float64{"CC": 2.5}, 3.25},
+		{"unlisted grades keep default points", map[string]float64{"DD": 0.5}, 3.0},
+		{"full US scale", gradeScales["us4"], 3.35},
+		{"pass/fail grades never get points", map[string]float64{"BL": 4.0}, 3.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := CalculateGPASummaryWithScale(courses, tt.scale)
+			if !approxEqual(summary.GPA, tt.gpa) {
+				t.Errorf("GPA = %v, want %v", summary.GPA, tt.gpa)
+			}
+		})
+	}
+
+	if got, want := CalculateGPASummary(courses), CalculateGPASummaryWithScale(courses, nil); got != want {
+		t.Errorf("CalculateGPASummary() = %+v, want the default scale %+v", got, want)
+	}
+}
+
+func TestNormalizeGrade(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"bb", "BB"},
+		{" ba+ ", "BA+"},
+		{"Devam", "Devam"},
+		{"DEVAM", "Devam"},
+		{"devam", "Devam"},
+		{"ng", "NG"},
+		{"w", "W"},
+		{"--", "--"},
+		{"", ""},
+		{"xx", "XX"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := normalizeGrade(tt.in)
+			if got != tt.want {
+				t.Errorf("normalizeGrade(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if tt.want != "XX" && !isKnownGrade(got) {
+				t.Errorf("normalizeGrade(%q) = %q, which isn't a known grade", tt.in, got)
+			}
+		})
+	}
+}
+
+func TestIsEarnedCourse(t *testing.T) {
+	tests := []struct {
+		name   string
+		course Course
+		want   bool
+	}{
+		{"letter grade", Course{Grade: "CC"}, true},
+		{"passed pass/fail", Course{Grade: "G"}, true},
+		{"failed", Course{Grade: "FF"}, false},
+		{"failed pass/fail", Course{Grade: "K"}, false},
+		{"in progress", Course{Grade: "Devam"}, false},
+		{"no grade", Course{Grade: "--"}, false},
+		{"withdrawn", Course{Grade: "W"}, false},
+		{"passed exchange", Course{Grade: "B", Exchange: true}, true},
+		{"failed exchange letter", Course{Grade: "F", Exchange: true}, false},
+		{"failed exchange numeric", Course{Grade: "4,3", Exchange: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isEarnedCourse(tt.course); got != tt.want {
+				t.Errorf("isEarnedCourse(%+v) = %v, want %v", tt.course, got, tt.want)
+			}
+		})
+	}
+}
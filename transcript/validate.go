@@ -0,0 +1,148 @@
+package transcript
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"encore.dev/beta/errs"
+)
+
+// semesterSignature is reported when the text contains semester headings
+const semesterSignature = "semester heading"
+
+// ValidateTranscriptPDFRequest represents the request body
+type ValidateTranscriptPDFRequest struct {
+	// PDF file content as base64 encoded string
+	PDFBase64 string `json:"pdf_base64"`
+	// Institution selects the expected transcript layout; defaults to defaultInstitution
+	Institution string `query:"institution"`
+}
+
+// ValidateTranscriptPDFResponse reports whether a PDF looks like a transcript
+type ValidateTranscriptPDFResponse struct {
+	IsTranscript      bool     `json:"isTranscript"`
+	MatchedSignatures []string `json:"matchedSignatures"`
+}
+
+// ValidateTranscriptPDF is a fast pre-check that extracts the PDF's text and
+// looks for transcript signatures without running the full parse. A PDF counts
+// as a transcript when it has semester headings and at least one document signature.
+//
+//encore:api public method=POST path=/validate-transcript-pdf
+func ValidateTranscriptPDF(ctx context.Context, req *ValidateTranscriptPDFRequest) (*ValidateTranscriptPDFResponse, error) {
+	profile, err := lookupInstitution(req.Institution)
+	if err != nil {
+		return nil, err
+	}
+
+	pdfBytes, err := decodePDFBase64(req.PDFBase64)
+	if err != nil {
+		return nil, pdfDecodeError(err)
+	}
+	if err := checkPDFContentType(pdfBytes); err != nil {
+		return nil, err
+	}
+
+	extractCtx, cancel := context.WithTimeout(ctx, pdfParseTimeout)
+	defer cancel()
+	extracted, err := extractTextWithRetry(extractCtx, pdfBytes)
+	if ctxErr := extractionContextError(err); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: "failed to extract text from PDF",
+		}
+	}
+
+	matched := []string{}
+	for _, signature := range profile.DocumentSignatures {
+		if strings.Contains(extracted.text, signature) {
+			matched = append(matched, signature)
+		}
+	}
+	hasDocumentSignature := len(matched) > 0
+
+	hasSemesters := profile.SemesterPattern.MatchString(extracted.text)
+	if hasSemesters {
+		matched = append(matched, semesterSignature)
+	}
+
+	return &ValidateTranscriptPDFResponse{
+		IsTranscript:      hasSemesters && hasDocumentSignature,
+		MatchedSignatures: matched,
+	}, nil
+}
+
+// contentTypeDescriptions names the files most often uploaded by mistake
+var contentTypeDescriptions = map[string]string{
+	"image/jpeg":      "a JPEG image",
+	"image/png":       "a PNG image",
+	"image/gif":       "a GIF image",
+	"image/webp":      "a WebP image",
+	"application/zip": "a ZIP archive, such as a Word .docx file",
+	"text/plain":      "plain text",
+	"text/html":       "an HTML page",
+}
+
+// checkPDFContentType sniffs decoded upload bytes and rejects anything that
+// isn't a PDF, naming what the file looks like instead
+func checkPDFContentType(data []byte) error {
+	contentType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	if contentType == "application/pdf" {
+		return nil
+	}
+
+	description, ok := contentTypeDescriptions[contentType]
+	if !ok {
+		description = contentType
+	}
+	return &errs.Error{
+		Code:    errs.InvalidArgument,
+		Message: fmt.Sprintf("pdf_base64 is not a PDF: the file looks like %s (%s)", description, contentType),
+	}
+}
+
+// errPDFTooLarge is returned when a decoded PDF exceeds maxPDFSizeBytes
+var errPDFTooLarge = errors.New("PDF is too large")
+
+// decodePDFBase64 decodes a base64 PDF, rejecting a payload whose length alone
+// shows it decodes to more than maxPDFSizeBytes before anything is allocated.
+// The request has already buffered the encoded string, so it is decoded in one
+// pass rather than through a streaming decoder, which is slower and saves nothing.
+func decodePDFBase64(encoded string) ([]byte, error) {
+	// The decoder skips line breaks and every 4 characters carry 3 bytes, of
+	// which padding removes at most 2
+	length := len(encoded) - strings.Count(encoded, "\n") - strings.Count(encoded, "\r")
+	if base64.StdEncoding.DecodedLen(length)-2 > maxPDFSizeBytes {
+		return nil, errPDFTooLarge
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) > maxPDFSizeBytes {
+		return nil, errPDFTooLarge
+	}
+	return decoded, nil
+}
+
+// pdfDecodeError maps a decodePDFBase64 failure to an API error
+func pdfDecodeError(err error) error {
+	if errors.Is(err, errPDFTooLarge) {
+		return &errs.Error{
+			Code:    errs.InvalidArgument,
+			Message: fmt.Sprintf("PDF exceeds the maximum size of %d MB", maxPDFSizeBytes>>20),
+		}
+	}
+	return &errs.Error{
+		Code:    errs.InvalidArgument,
+		Message: "pdf_base64 is not valid base64",
+	}
+}
@@ -0,0 +1,118 @@
+package transcript
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"encore.dev/beta/errs"
+)
+
+func TestDecodePDFBase64(t *testing.T) {
+	original := maxPDFSizeBytes
+	maxPDFSizeBytes = 16
+	defer func() { maxPDFSizeBytes = original }()
+
+	tests := []struct {
+		name    string
+		encoded string
+		want    []byte
+		wantErr error
+	}{
+		{"empty", "", []byte{}, nil},
+		{"within the limit", base64.StdEncoding.EncodeToString([]byte("%PDF-1.7 small")), []byte("%PDF-1.7 small"), nil},
+		{"at the limit", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("x"), 16)), bytes.Repeat([]byte("x"), 16), nil},
+		{"over the limit", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("x"), 17)), nil, errPDFTooLarge},
+		{"far over the limit", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("x"), 64)), nil, errPDFTooLarge},
+		{"line breaks at the limit", "eHh4eHh4eHh4\r\neHh4eHh4eA==", bytes.Repeat([]byte("x"), 16), nil},
+		{"line breaks over the limit", "eHh4eHh4eHh4\neHh4eHh4eHh4", nil, errPDFTooLarge},
+		{"invalid base64", "not base64!", nil, base64.CorruptInputError(3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePDFBase64(tt.encoded)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("decodePDFBase64() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodePDFBase64() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("decodePDFBase64() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPDFContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		wantMessage string // empty when the data is accepted as a PDF
+	}{
+		{"PDF", []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n1 0 obj"), ""},
+		{"PNG", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), "looks like a PNG image (image/png)"},
+		{"JPEG", []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"), "looks like a JPEG image (image/jpeg)"},
+		{"DOCX", []byte("PK\x03\x04\x14\x00\x06\x00"), "looks like a ZIP archive, such as a Word .docx file (application/zip)"},
+		{"text file", []byte("BLG 101E Introduction to Computing 3 BB\n"), "looks like plain text (text/plain)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPDFContentType(tt.data)
+			if tt.wantMessage == "" {
+				if err != nil {
+					t.Fatalf("checkPDFContentType() = %v, want nil", err)
+				}
+				return
+			}
+			var apiErr *errs.Error
+			if !errors.As(err, &apiErr) || apiErr.Code != errs.InvalidArgument {
+				t.Fatalf("checkPDFContentType() = %v, want InvalidArgument", err)
+			}
+			if !strings.Contains(apiErr.Message, tt.wantMessage) {
+				t.Errorf("message = %q, want it to say %q", apiErr.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+// BenchmarkDecodePDFBase64 compares decodePDFBase64 with a plain DecodeString;
+// run with -benchmem to see the allocations. An oversized payload is rejected
+// from its length without decoding.
+func BenchmarkDecodePDFBase64(b *testing.B) {
+	encoded := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("%PDF-1.7 transcript "), 512<<10))
+
+	b.Run("DecodeString", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := base64.StdEncoding.DecodeString(encoded); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("decodePDFBase64", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := decodePDFBase64(encoded); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("decodePDFBase64 over the limit", func(b *testing.B) {
+		original := maxPDFSizeBytes
+		maxPDFSizeBytes = 1 << 20
+		defer func() { maxPDFSizeBytes = original }()
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := decodePDFBase64(encoded); !errors.Is(err, errPDFTooLarge) {
+				b.Fatalf("decodePDFBase64() error = %v, want errPDFTooLarge", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package transcript
+
+import (
+	"encore.dev/middleware"
+)
+
+// APIVersion identifies the shape of the transcript JSON responses and is sent
+// as the X-API-Version header. Bump it whenever course fields are added or
+// change meaning (e.g. Points, ECTS, Language) so clients can detect it.
+//
+// Versions since 5:
+//   - 6: courses carry credits_from_catalog when their credits were taken from
+//     the course catalog because the transcript listed 0
+//   - 7: courses carry in_progress when they have no grade yet, either flagged
+//     by the parser or marked with a configured marker such as "Devam" or "NG"
+const APIVersion = "7"
+
+// versionedResponse is implemented by responses that carry the X-API-Version header
+type versionedResponse interface {
+	setAPIVersion(version string)
+}
+
+// APIVersionMiddleware stamps the API version onto every versioned response.
+//
+//encore:middleware global target=all
+func APIVersionMiddleware(req middleware.Request, next middleware.Next) middleware.Response {
+	resp := next(req)
+	if versioned, ok := resp.Payload.(versionedResponse); ok {
+		versioned.setAPIVersion(APIVersion)
+	}
+	return resp
+}
+
+func (r *ParseTranscriptResponse) setAPIVersion(v string)         { r.APIVersion = v }
+func (r *ParseAndStoreTranscriptResponse) setAPIVersion(v string) { r.APIVersion = v }
+func (r *AppendParseResponse) setAPIVersion(v string)             { r.APIVersion = v }
+func (r *GetTranscriptResponse) setAPIVersion(v string)           { r.APIVersion = v }
+func (r *ListTranscriptsResponse) setAPIVersion(v string)         { r.APIVersion = v }
+func (r *ListCoursesResponse) setAPIVersion(v string)             { r.APIVersion = v }